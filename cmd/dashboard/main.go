@@ -1,5 +1,5 @@
 /*
-Copyright 2019-2024 The Tekton Authors
+Copyright 2019-2026 The Tekton Authors
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
@@ -14,13 +14,24 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"embed"
 	"flag"
+	"io/fs"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/tektoncd/dashboard/pkg/clusters"
 	"github.com/tektoncd/dashboard/pkg/endpoints"
+	"github.com/tektoncd/dashboard/pkg/externallogs"
 	"github.com/tektoncd/dashboard/pkg/logging"
 	"github.com/tektoncd/dashboard/pkg/router"
+	"github.com/tektoncd/dashboard/pkg/runs"
+	"github.com/tektoncd/dashboard/pkg/search"
+	"github.com/tektoncd/dashboard/pkg/stats"
+	"github.com/tektoncd/dashboard/pkg/watchcache"
+	"k8s.io/client-go/dynamic"
 	k8sclientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
@@ -38,8 +49,99 @@ var (
 	streamLogs         = flag.Bool("stream-logs", true, "Enable log streaming instead of polling")
 	externalLogs       = flag.String("external-logs", "", "External logs provider URL")
 	xFrameOptions      = flag.String("x-frame-options", "DENY", "Value for the X-Frame-Options response header, set '' to omit it")
+	basePath           = flag.String("base-path", "", "If set, serves the Dashboard under this path prefix instead of the root, e.g. /tekton")
+	webDir             = flag.String("web-dir", "", "If set, serves the web UI from this directory instead of the resources embedded in the binary")
+	externalURL        = flag.String("external-url", "", "URL users access the Dashboard at, e.g. https://dashboard.example.com/tekton, used to validate the origin of requests")
+	allowedOrigins     = flag.String("allowed-origins", "", "Comma-separated list of additional origins allowed to send requests, e.g. https://portal.example.com or https://*.example.com")
+	trustedProxies     = flag.String("trusted-proxies", "", "Comma-separated list of CIDRs of proxies whose X-Forwarded-Host header is trusted")
+	rateLimit          = flag.Bool("rate-limit", false, "Enable per client rate limits and concurrency caps for API requests")
+	rateLimitUser      = flag.String("rate-limit-user-header", "", "Header set by one of the trusted proxies identifying the user sending a request for rate limiting, e.g. X-Forwarded-User, clients are identified by IP address otherwise")
+	rateLimitList      = flag.String("rate-limit-list", "qps=20,burst=40", "Rate limit for get and list requests, in the form qps=N,burst=N,concurrent=N")
+	rateLimitWatch     = flag.String("rate-limit-watch", "qps=2,burst=20,concurrent=30", "Rate limit for watch requests, in the form qps=N,burst=N,concurrent=N")
+	rateLimitLog       = flag.String("rate-limit-log", "qps=5,burst=20,concurrent=20", "Rate limit for log requests, in the form qps=N,burst=N,concurrent=N")
+	rateLimitMutate    = flag.String("rate-limit-mutate", "qps=5,burst=10", "Rate limit for create, update, patch and delete requests, in the form qps=N,burst=N,concurrent=N")
+	csrfMode           = flag.String("csrf-mode", "header", "CSRF protection mode: 'header' requires a custom header on unsafe requests, 'token' requires a signed token and same-origin Origin or Referer header")
+	csrfSecretFile     = flag.String("csrf-secret-file", "", "File containing the key used to sign CSRF tokens in token mode, required when running multiple replicas")
+	watchCache         = flag.Bool("watch-cache", false, "Serve list and watch requests for Tekton resources from a shared in-memory cache instead of the API server")
+	watchCacheRes      = flag.String("watch-cache-resources", "pipelineruns,taskruns", "Comma-separated list of Tekton resources served from the watch cache")
+	runStats           = flag.Bool("stats", false, "Serve aggregated PipelineRun and TaskRun statistics from an in-memory index of the runs")
+	searchAPI          = flag.Bool("search", false, "Serve full-text search of Tekton resources from an in-memory index")
+	runsAPI            = flag.Bool("runs-api", false, "Serve filtered, sorted and paginated lists of PipelineRuns and TaskRuns from an in-memory index of the runs")
+	clustersConfig     = flag.String("clusters-kubeconfig", "", "Kubeconfig file with a context for each additional cluster managed by the Dashboard, named after the context")
+	clusterSecrets     = flag.Bool("cluster-secrets", false, "Add a cluster for each Secret labelled dashboard.tekton.dev/cluster in the Dashboard's namespace, containing a kubeconfig")
+	brandingDir        = flag.String("branding-dir", "", "Directory containing a branding.yaml file and assets customising the title, logo, links and banner of the Dashboard")
+
+	externalLogsTokenFile    = flag.String("external-logs-token-file", "", "File containing a bearer token sent to the external logs provider")
+	externalLogsBasicAuthDir = flag.String("external-logs-basic-auth-dir", "", "Directory containing 'username' and 'password' files used for basic authentication with the external logs provider")
+	externalLogsCAFile       = flag.String("external-logs-ca-file", "", "File containing PEM encoded CA certificates used to verify the external logs provider")
+	externalLogsCertFile     = flag.String("external-logs-cert-file", "", "File containing a PEM encoded client certificate for the external logs provider")
+	externalLogsKeyFile      = flag.String("external-logs-key-file", "", "File containing the PEM encoded private key for the external logs provider client certificate")
+	externalLogsHeaders      stringSlice
+
+	externalLogsDialTimeout           = flag.Duration("external-logs-dial-timeout", 10*time.Second, "Timeout for establishing a connection to the external logs provider")
+	externalLogsResponseHeaderTimeout = flag.Duration("external-logs-response-header-timeout", 30*time.Second, "Timeout waiting for the external logs provider to send response headers")
+	externalLogsMaxRetries            = flag.Int("external-logs-max-retries", 2, "Number of times a failed request to the external logs provider is retried")
+	externalLogsBreakerThreshold      = flag.Int("external-logs-breaker-failure-threshold", 5, "Consecutive failures after which requests to the external logs provider fail fast, 0 to disable")
+	externalLogsBreakerResetTimeout   = flag.Duration("external-logs-breaker-reset-timeout", 30*time.Second, "Time requests to the external logs provider fail fast before it is tried again")
+
+	cspScriptSrc  = flag.String("csp-script-src", "", "Comma-separated list of additional sources allowed by the Content-Security-Policy script-src directive")
+	cspStyleSrc   = flag.String("csp-style-src", "", "Comma-separated list of additional sources allowed by the Content-Security-Policy style-src directive")
+	cspConnectSrc = flag.String("csp-connect-src", "", "Comma-separated list of additional sources allowed by the Content-Security-Policy connect-src directive")
+	cspImgSrc     = flag.String("csp-img-src", "", "Comma-separated list of additional sources allowed by the Content-Security-Policy img-src directive")
+	cspFontSrc    = flag.String("csp-font-src", "https://1.www.s81c.com", "Comma-separated list of additional sources allowed by the Content-Security-Policy font-src directive")
+	cspStyleNonce = flag.Bool("csp-style-nonce", false, "Require inline styles to carry the Content-Security-Policy nonce instead of allowing 'unsafe-inline'")
+	cspReportURI  = flag.String("csp-report-uri", "", "URI to which Content-Security-Policy violations are reported, e.g. /v1/csp-report to log them in the Dashboard")
+	cspReportTo   = flag.String("csp-report-to", "", "Name of the Reporting API endpoint for Content-Security-Policy violations, sent to the --csp-report-uri")
+
+	hsts                      = flag.String("strict-transport-security", "max-age=31536000", "Value for the Strict-Transport-Security response header sent on requests received over TLS, set '' to omit it")
+	referrerPolicy            = flag.String("referrer-policy", "same-origin", "Value for the Referrer-Policy response header, set '' to omit it")
+	permissionsPolicy         = flag.String("permissions-policy", "camera=(), geolocation=(), microphone=(), payment=(), usb=()", "Value for the Permissions-Policy response header, set '' to omit it")
+	contentTypeOptions        = flag.String("x-content-type-options", "nosniff", "Value for the X-Content-Type-Options response header, set '' to omit it")
+	crossOriginOpenerPolicy   = flag.String("cross-origin-opener-policy", "same-origin", "Value for the Cross-Origin-Opener-Policy response header, set '' to omit it")
+	crossOriginResourcePolicy = flag.String("cross-origin-resource-policy", "same-origin", "Value for the Cross-Origin-Resource-Policy response header, set '' to omit it")
+	frameAncestors            = flag.String("frame-ancestors", "", "Comma-separated list of sources allowed to embed the Dashboard in a frame, overrides the frame-ancestors derived from --x-frame-options")
 )
 
+// webResources contains the web UI built by 'npm run build'
+//
+//go:embed all:web
+var webResources embed.FS
+
+// getWebResources returns the web UI resources to serve, from the given
+// directory if set or the resources embedded in the binary otherwise
+func getWebResources(dir string) (fs.FS, error) {
+	if dir != "" {
+		return os.DirFS(dir), nil
+	}
+	return fs.Sub(webResources, "web/dist")
+}
+
+// normalizeBasePath ensures a base path has a leading slash and no trailing
+// slash, returning an empty string when serving from the root
+func normalizeBasePath(basePath string) string {
+	basePath = strings.Trim(basePath, "/")
+	if basePath == "" {
+		return ""
+	}
+	return "/" + basePath
+}
+
+func init() {
+	flag.Var(&externalLogsHeaders, "external-logs-header", "Header added to requests to the external logs provider in the form 'Name: value', can be repeated")
+}
+
+// stringSlice is a flag.Value collecting the values of a repeated flag
+type stringSlice []string
+
+func (s *stringSlice) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSlice) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
 func main() {
 	flag.Parse()
 	installNamespace := os.Getenv("INSTALLED_NAMESPACE")
@@ -56,6 +158,11 @@ func main() {
 		logging.Log.Errorf("Error building k8s clientset: %s", err.Error())
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		logging.Log.Errorf("Error building dynamic client: %s", err.Error())
+	}
+
 	// use FieldsFunc instead of Split as Split returns an array containing an empty string
 	// instead of the desired empty array when there is no delimeter (i.e. empty string or single namespace)
 	splitByComma := func(c rune) bool {
@@ -73,13 +180,171 @@ func main() {
 		LogoutURL:          *logoutURL,
 		StreamLogs:         *streamLogs,
 		ExternalLogsURL:    *externalLogs,
-		XFrameOptions:      *xFrameOptions,
+		BasePath:           normalizeBasePath(*basePath),
+		ExternalURL:        *externalURL,
+		AllowedOrigins:     strings.FieldsFunc(*allowedOrigins, splitByComma),
+		TrustedProxies:     strings.FieldsFunc(*trustedProxies, splitByComma),
+		BrandingDir:        *brandingDir,
+		ContentSecurityPolicy: endpoints.ContentSecurityPolicyOptions{
+			ScriptSrc:  strings.FieldsFunc(*cspScriptSrc, splitByComma),
+			StyleSrc:   strings.FieldsFunc(*cspStyleSrc, splitByComma),
+			ConnectSrc: strings.FieldsFunc(*cspConnectSrc, splitByComma),
+			ImgSrc:     strings.FieldsFunc(*cspImgSrc, splitByComma),
+			FontSrc:    strings.FieldsFunc(*cspFontSrc, splitByComma),
+			StyleNonce: *cspStyleNonce,
+			ReportURI:  *cspReportURI,
+			ReportTo:   *cspReportTo,
+		},
+		SecurityHeaders: endpoints.SecurityHeadersOptions{
+			StrictTransportSecurity:   *hsts,
+			ReferrerPolicy:            *referrerPolicy,
+			PermissionsPolicy:         *permissionsPolicy,
+			ContentTypeOptions:        *contentTypeOptions,
+			CrossOriginOpenerPolicy:   *crossOriginOpenerPolicy,
+			CrossOriginResourcePolicy: *crossOriginResourcePolicy,
+			XFrameOptions:             *xFrameOptions,
+			FrameAncestors:            strings.FieldsFunc(*frameAncestors, splitByComma),
+		},
+		CSRF: endpoints.CSRFOptions{
+			Mode:       *csrfMode,
+			SecretFile: *csrfSecretFile,
+		},
+		RateLimit: endpoints.RateLimitOptions{
+			Enabled:    *rateLimit,
+			UserHeader: *rateLimitUser,
+			List:       *rateLimitList,
+			Watch:      *rateLimitWatch,
+			Log:        *rateLimitLog,
+			Mutate:     *rateLimitMutate,
+		},
+	}
+
+	if options.Branding, err = endpoints.LoadBranding(*brandingDir); err != nil {
+		logging.Log.Errorf("Error loading branding: %s", err.Error())
+		return
+	}
+
+	headers, err := externallogs.ParseHeaders(externalLogsHeaders)
+	if err != nil {
+		logging.Log.Errorf("Error parsing external logs headers: %s", err.Error())
+		return
+	}
+	externalLogsClient, err := externallogs.NewClient(externallogs.Options{
+		TokenFile:    *externalLogsTokenFile,
+		BasicAuthDir: *externalLogsBasicAuthDir,
+		Headers:      headers,
+		CAFile:       *externalLogsCAFile,
+		CertFile:     *externalLogsCertFile,
+		KeyFile:      *externalLogsKeyFile,
+
+		DialTimeout:             *externalLogsDialTimeout,
+		ResponseHeaderTimeout:   *externalLogsResponseHeaderTimeout,
+		MaxRetries:              *externalLogsMaxRetries,
+		BreakerFailureThreshold: *externalLogsBreakerThreshold,
+		BreakerResetTimeout:     *externalLogsBreakerResetTimeout,
+	})
+	if err != nil {
+		logging.Log.Errorf("Error building external logs client: %s", err.Error())
+		return
+	}
+
+	web, err := getWebResources(*webDir)
+	if err != nil {
+		logging.Log.Errorf("Error loading web resources: %s", err.Error())
+		return
+	}
+	if _, err := fs.Stat(web, "index.html"); err != nil {
+		logging.Log.Warnf("Web UI not found, only the APIs will be available: %s", err.Error())
+	}
+
+	var announcements *endpoints.Announcements
+	if installNamespace != "" {
+		announcements = endpoints.NewAnnouncements(k8sClient, installNamespace)
+		go func() {
+			if err := announcements.Run(context.Background()); err != nil {
+				logging.Log.Errorf("Error watching announcements: %s", err.Error())
+			}
+		}()
+	}
+
+	var cache *watchcache.Cache
+	if *watchCache {
+		cache, err = watchcache.New(dynamicClient, tenants, strings.FieldsFunc(*watchCacheRes, splitByComma))
+		if err != nil {
+			logging.Log.Errorf("Error creating watch cache: %s", err.Error())
+			return
+		}
+		go func() {
+			if err := cache.Run(context.Background()); err != nil {
+				logging.Log.Errorf("Error running watch cache: %s", err.Error())
+			}
+		}()
+	}
+
+	var runIndex *runs.Index
+	var statsIndex *stats.Index
+	if *runStats || *runsAPI {
+		index := runs.New(dynamicClient, tenants)
+		go func() {
+			if err := index.Run(context.Background()); err != nil {
+				logging.Log.Errorf("Error indexing runs: %s", err.Error())
+			}
+		}()
+		if *runsAPI {
+			runIndex = index
+		}
+		if *runStats {
+			statsIndex = stats.New(index)
+		}
+	}
+
+	var searchIndex *search.Index
+	if *searchAPI {
+		searchIndex = search.New(dynamicClient, k8sClient.Discovery(), tenants)
+		go func() {
+			if err := searchIndex.Run(context.Background()); err != nil {
+				logging.Log.Errorf("Error indexing resources for search: %s", err.Error())
+			}
+		}()
+	}
+
+	var registry *clusters.Registry
+	if *clustersConfig != "" || *clusterSecrets {
+		registry = clusters.NewRegistry(cfg, k8sClient)
+		if *clustersConfig != "" {
+			if err := registry.LoadKubeconfig(*clustersConfig); err != nil {
+				logging.Log.Errorf("Error loading clusters: %s", err.Error())
+				return
+			}
+		}
+		if *clusterSecrets {
+			if installNamespace == "" {
+				logging.Log.Warn("Dashboard namespace unknown, clusters will not be loaded from Secrets")
+			} else {
+				registry.WatchSecrets(k8sClient, installNamespace)
+			}
+		}
+		go func() {
+			if err := registry.Run(context.Background()); err != nil {
+				logging.Log.Errorf("Error watching clusters: %s", err.Error())
+			}
+		}()
 	}
 
 	resource := endpoints.Resource{
-		Config:    cfg,
-		K8sClient: k8sClient,
-		Options:   options,
+		Config:             cfg,
+		K8sClient:          k8sClient,
+		DynamicClient:      dynamicClient,
+		ExternalLogsClient: externalLogsClient,
+		WebResources:       web,
+		Announcements:      announcements,
+		AccessReviews:      endpoints.NewAccessReviews(),
+		Clusters:           registry,
+		Runs:               runIndex,
+		Search:             searchIndex,
+		Stats:              statsIndex,
+		WatchCache:         cache,
+		Options:            options,
 	}
 
 	server, err := router.Register(resource, cfg)
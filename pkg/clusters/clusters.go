@@ -0,0 +1,340 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clusters keeps a registry of the clusters managed by the Dashboard
+package clusters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// Local is the name of the cluster the Dashboard is running in
+	Local = "local"
+
+	// SecretLabel marks Secrets containing the kubeconfig of a cluster
+	SecretLabel = "dashboard.tekton.dev/cluster"
+	// SecretNameAnnotation overrides the name of the cluster, which defaults
+	// to the name of the Secret
+	SecretNameAnnotation = "dashboard.tekton.dev/cluster-name"
+	// SecretKey is the key of the kubeconfig in the Secret
+	SecretKey = "kubeconfig"
+
+	// healthTimeout limits the time waiting for a cluster's readiness check
+	healthTimeout = 5 * time.Second
+)
+
+// Sources of a cluster
+const (
+	SourceLocal      = "local"
+	SourceKubeconfig = "kubeconfig"
+	SourceSecret     = "secret"
+)
+
+// namePattern restricts cluster names to a single path segment
+var namePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Cluster is a cluster managed by the Dashboard
+type Cluster struct {
+	Name   string
+	Source string
+	Config *rest.Config
+	Client kubernetes.Interface
+
+	handler    http.Handler
+	handlerErr error
+	once       sync.Once
+}
+
+// Info describes a cluster for the cluster selector
+type Info struct {
+	Name    string `json:"name"`
+	Source  string `json:"source"`
+	Server  string `json:"server"`
+	Default bool   `json:"default,omitempty"`
+}
+
+// Health is the result of a cluster's readiness check
+type Health struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Version string `json:"version,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Registry holds the clusters managed by the Dashboard, from a kubeconfig
+// file and from labelled Secrets
+type Registry struct {
+	mu       sync.RWMutex
+	clusters map[string]*Cluster
+	// secrets maps the name of each Secret to the cluster it defines
+	secrets map[string]string
+
+	informer cache.SharedIndexInformer
+	factory  informers.SharedInformerFactory
+}
+
+// NewRegistry returns a Registry containing the local cluster
+func NewRegistry(cfg *rest.Config, client kubernetes.Interface) *Registry {
+	r := &Registry{
+		clusters: map[string]*Cluster{},
+		secrets:  map[string]string{},
+	}
+	r.clusters[Local] = &Cluster{Name: Local, Source: SourceLocal, Config: cfg, Client: client}
+	return r
+}
+
+func newCluster(name, source string, cfg *rest.Config) (*Cluster, error) {
+	if !namePattern.MatchString(name) || name == Local {
+		return nil, fmt.Errorf("invalid cluster name %q, expected a DNS label other than %q", name, Local)
+	}
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error building client for cluster %q: %w", name, err)
+	}
+	return &Cluster{Name: name, Source: source, Config: cfg, Client: client}, nil
+}
+
+// LoadKubeconfig adds a cluster for each context of the kubeconfig file,
+// named after the context
+func (r *Registry) LoadKubeconfig(path string) error {
+	kubeconfig, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		return fmt.Errorf("error loading clusters kubeconfig: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name := range kubeconfig.Contexts {
+		cfg, err := clientcmd.NewNonInteractiveClientConfig(*kubeconfig, name, &clientcmd.ConfigOverrides{}, nil).ClientConfig()
+		if err != nil {
+			return fmt.Errorf("error loading context %q: %w", name, err)
+		}
+		cluster, err := newCluster(name, SourceKubeconfig, cfg)
+		if err != nil {
+			return err
+		}
+		if _, ok := r.clusters[name]; ok {
+			return fmt.Errorf("duplicate cluster %q", name)
+		}
+		r.clusters[name] = cluster
+		logging.Log.Infof("Added cluster %s from kubeconfig", name)
+	}
+	return nil
+}
+
+// WatchSecrets adds a cluster for each Secret in the namespace labelled with
+// SecretLabel, using the current context of its kubeconfig. Call Run to start
+// watching.
+func (r *Registry) WatchSecrets(client kubernetes.Interface, namespace string) {
+	r.factory = informers.NewSharedInformerFactoryWithOptions(client, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = SecretLabel
+		}),
+	)
+	r.informer = r.factory.Core().V1().Secrets().Informer()
+}
+
+// Run keeps the clusters defined by Secrets up to date until the context is
+// done. It returns immediately if WatchSecrets was not called.
+func (r *Registry) Run(ctx context.Context) error {
+	if r.informer == nil {
+		return nil
+	}
+	if _, err := r.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    r.onSecret,
+		UpdateFunc: func(_, obj any) { r.onSecret(obj) },
+		DeleteFunc: r.onSecretDeleted,
+	}); err != nil {
+		return err
+	}
+	r.factory.Start(ctx.Done())
+	<-ctx.Done()
+	r.factory.Shutdown()
+	return nil
+}
+
+func secretClusterName(secret *corev1.Secret) string {
+	if name := secret.Annotations[SecretNameAnnotation]; name != "" {
+		return name
+	}
+	return secret.Name
+}
+
+// secretRESTConfig loads the current context of a kubeconfig from a Secret.
+// Anyone able to create Secrets in the Dashboard's namespace can add a
+// cluster, so the kubeconfig must contain its credentials and certificates
+// inline, and must not run exec plugins or auth providers or read files from
+// the Dashboard's filesystem.
+func secretRESTConfig(data []byte) (*rest.Config, error) {
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return nil, err
+	}
+	for name, cluster := range config.Clusters {
+		if cluster.CertificateAuthority != "" {
+			return nil, fmt.Errorf("cluster %q uses certificate-authority, only certificate-authority-data is supported", name)
+		}
+	}
+	for name, authInfo := range config.AuthInfos {
+		var field string
+		switch {
+		case authInfo.Exec != nil:
+			field = "exec"
+		case authInfo.AuthProvider != nil:
+			field = "auth-provider"
+		case authInfo.TokenFile != "":
+			field = "tokenFile"
+		case authInfo.ClientCertificate != "":
+			field = "client-certificate"
+		case authInfo.ClientKey != "":
+			field = "client-key"
+		default:
+			continue
+		}
+		return nil, fmt.Errorf("user %q uses %s, only inline credentials are supported", name, field)
+	}
+	return clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{}).ClientConfig()
+}
+
+func (r *Registry) onSecret(obj any) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return
+	}
+	r.removeSecret(secret.Name)
+
+	name := secretClusterName(secret)
+	cfg, err := secretRESTConfig(secret.Data[SecretKey])
+	if err != nil {
+		logging.Log.Errorf("Error loading kubeconfig of cluster %s from Secret %s: %s", name, secret.Name, err.Error())
+		return
+	}
+	cluster, err := newCluster(name, SourceSecret, cfg)
+	if err != nil {
+		logging.Log.Errorf("Error adding cluster from Secret %s: %s", secret.Name, err.Error())
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.clusters[name]; ok {
+		logging.Log.Errorf("Error adding cluster from Secret %s: duplicate cluster %q", secret.Name, name)
+		return
+	}
+	r.clusters[name] = cluster
+	r.secrets[secret.Name] = name
+	logging.Log.Infof("Added cluster %s from Secret %s", name, secret.Name)
+}
+
+func (r *Registry) onSecretDeleted(obj any) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if secret, ok := obj.(*corev1.Secret); ok {
+		r.removeSecret(secret.Name)
+	}
+}
+
+// removeSecret removes the cluster defined by a Secret, if any
+func (r *Registry) removeSecret(secretName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if name, ok := r.secrets[secretName]; ok {
+		delete(r.clusters, name)
+		delete(r.secrets, secretName)
+		logging.Log.Infof("Removed cluster %s", name)
+	}
+}
+
+// Get returns the named cluster
+func (r *Registry) Get(name string) (*Cluster, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	cluster, ok := r.clusters[name]
+	return cluster, ok
+}
+
+// List describes the clusters, sorted by name with the local cluster first
+func (r *Registry) List() []Info {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	list := make([]Info, 0, len(r.clusters))
+	for _, cluster := range r.clusters {
+		list = append(list, Info{
+			Name:    cluster.Name,
+			Source:  cluster.Source,
+			Server:  cluster.Config.Host,
+			Default: cluster.Name == Local,
+		})
+	}
+	slices.SortFunc(list, func(a, b Info) int {
+		switch {
+		case a.Default:
+			return -1
+		case b.Default:
+			return 1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return list
+}
+
+// Handler returns the proxy handler for the cluster's API server, created by
+// newHandler on first use
+func (c *Cluster) Handler(newHandler func(*rest.Config) (http.Handler, error)) (http.Handler, error) {
+	c.once.Do(func() {
+		c.handler, c.handlerErr = newHandler(c.Config)
+	})
+	return c.handler, c.handlerErr
+}
+
+// CheckHealth checks the readiness of the cluster's API server
+func (c *Cluster) CheckHealth(ctx context.Context) Health {
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+
+	health := Health{Name: c.Name}
+	discovery := c.Client.Discovery()
+	if err := discovery.RESTClient().Get().AbsPath("/readyz").Do(ctx).Error(); err != nil {
+		health.Error = err.Error()
+		return health
+	}
+	health.Healthy = true
+	// ServerVersion doesn't take a context, so would not respect the timeout
+	body, err := discovery.RESTClient().Get().AbsPath("/version").Do(ctx).Raw()
+	var info version.Info
+	if err == nil && json.Unmarshal(body, &info) == nil {
+		health.Version = info.GitVersion
+	}
+	return health
+}
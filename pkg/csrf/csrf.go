@@ -1,5 +1,5 @@
 /*
-Copyright 2021-2024 The Tekton Authors
+Copyright 2021-2026 The Tekton Authors
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
@@ -14,9 +14,22 @@ limitations under the License.
 package csrf
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+
+	"github.com/tektoncd/dashboard/pkg/origins"
+)
+
+// Modes of CSRF protection
+const (
+	// ModeHeader requires a custom header on unsafe requests, relying on the
+	// CORS preflight to block cross-site requests setting it
+	ModeHeader = "header"
+	// ModeToken requires a signed double-submit token and a same-origin
+	// Origin or Referer header on unsafe requests
+	ModeToken = "token"
 )
 
 var (
@@ -39,6 +52,21 @@ type csrf struct {
 type options struct {
 	ErrorHandler http.Handler
 	HeaderName   string
+	ExemptPaths  map[string]bool
+
+	Mode       string
+	Secret     []byte
+	TokenPath  string
+	CookiePath string
+	Origins    *origins.Policy
+}
+
+type failureReasonKey struct{}
+
+// FailureReason returns the reason a request was rejected, for use by an ErrorHandler
+func FailureReason(r *http.Request) error {
+	err, _ := r.Context().Value(failureReasonKey{}).(error)
+	return err
 }
 
 // Option contains configuration for the CSRF wrapper
@@ -57,16 +85,25 @@ func Protect(opts ...Option) func(http.Handler) http.Handler {
 			cs.opts.HeaderName = headerName
 		}
 
+		if cs.opts.CookiePath == "" {
+			cs.opts.CookiePath = "/"
+		}
+
 		return cs
 	}
 }
 
 // Implements http.Handler for the csrf type.
 func (cs *csrf) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if _, ok := safeMethods[r.Method]; !ok {
+	if cs.opts.Mode == ModeToken {
+		cs.serveTokenMode(w, r)
+		return
+	}
+
+	if _, ok := safeMethods[r.Method]; !ok && !cs.opts.ExemptPaths[r.URL.Path] {
 		csrfHeader := r.Header.Get(cs.opts.HeaderName)
 		if csrfHeader == "" {
-			cs.opts.ErrorHandler.ServeHTTP(w, r)
+			cs.fail(w, r, errNoHeader)
 			return
 		}
 	}
@@ -74,9 +111,38 @@ func (cs *csrf) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	cs.h.ServeHTTP(w, r)
 }
 
-func unauthorizedHandler(w http.ResponseWriter, _ *http.Request) {
+func (cs *csrf) serveTokenMode(w http.ResponseWriter, r *http.Request) {
+	if _, ok := safeMethods[r.Method]; ok {
+		if cs.opts.TokenPath != "" && r.URL.Path == cs.opts.TokenPath && r.Method == http.MethodGet {
+			cs.serveToken(w, r)
+			return
+		}
+		// Issue a token with the first response so it is available to the web UI
+		if _, err := cs.ensureToken(w, r); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	} else if !cs.opts.ExemptPaths[r.URL.Path] {
+		if err := cs.checkOrigin(r); err != nil {
+			cs.fail(w, r, err)
+			return
+		}
+		if err := cs.checkToken(r); err != nil {
+			cs.fail(w, r, err)
+			return
+		}
+	}
+
+	cs.h.ServeHTTP(w, r)
+}
+
+func (cs *csrf) fail(w http.ResponseWriter, r *http.Request, err error) {
+	cs.opts.ErrorHandler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), failureReasonKey{}, err)))
+}
+
+func unauthorizedHandler(w http.ResponseWriter, r *http.Request) {
 	http.Error(w, fmt.Sprintf("%s - %s",
-		http.StatusText(http.StatusForbidden), errNoHeader),
+		http.StatusText(http.StatusForbidden), FailureReason(r)),
 		http.StatusForbidden)
 }
 
@@ -105,3 +171,27 @@ func HeaderName(header string) Option {
 		cs.opts.HeaderName = header
 	}
 }
+
+// ExemptPath disables CSRF protection for requests to the given path, for
+// endpoints called by the browser itself such as violation reports
+func ExemptPath(path string) Option {
+	return func(cs *csrf) {
+		if cs.opts.ExemptPaths == nil {
+			cs.opts.ExemptPaths = map[string]bool{}
+		}
+		cs.opts.ExemptPaths[path] = true
+	}
+}
+
+// TokenMode enables the token mode of CSRF protection. Tokens are signed with
+// the secret and served at tokenPath, and cookies are scoped to cookiePath.
+// Unsafe requests must originate from an origin allowed by the policy.
+func TokenMode(secret []byte, tokenPath, cookiePath string, policy *origins.Policy) Option {
+	return func(cs *csrf) {
+		cs.opts.Mode = ModeToken
+		cs.opts.Secret = secret
+		cs.opts.TokenPath = tokenPath
+		cs.opts.CookiePath = cookiePath
+		cs.opts.Origins = policy
+	}
+}
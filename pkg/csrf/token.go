@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csrf
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// TokenCookieName is the cookie holding the CSRF token in token mode
+	TokenCookieName = "tekton-csrf"
+	// TokenHeaderName is the header which must echo the CSRF token in token mode
+	TokenHeaderName = "Tekton-Csrf-Token"
+
+	tokenNonceSize = 32
+)
+
+var (
+	errNoToken       = errors.New("CSRF token not found in request")
+	errInvalidToken  = errors.New("CSRF token invalid")
+	errNoOrigin      = errors.New("origin or referer not found in request")
+	errInvalidOrigin = errors.New("origin not allowed")
+)
+
+// NewSecret returns a random secret for signing CSRF tokens
+func NewSecret() ([]byte, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+func (cs *csrf) sign(nonce []byte) []byte {
+	mac := hmac.New(sha256.New, cs.opts.Secret)
+	mac.Write(nonce)
+	return mac.Sum(nil)
+}
+
+// newToken returns a random nonce and its signature, so tokens cannot be
+// forged by an attacker able to set cookies for the Dashboard's domain
+func (cs *csrf) newToken() (string, error) {
+	nonce := make([]byte, tokenNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(nonce) + "." + base64.RawURLEncoding.EncodeToString(cs.sign(nonce)), nil
+}
+
+func (cs *csrf) isValidToken(token string) bool {
+	encodedNonce, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		return false
+	}
+	nonce, err := base64.RawURLEncoding.DecodeString(encodedNonce)
+	if err != nil || len(nonce) != tokenNonceSize {
+		return false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(signature, cs.sign(nonce))
+}
+
+// cookieToken returns the token from the request's cookie if it is valid
+func (cs *csrf) cookieToken(r *http.Request) string {
+	cookie, err := r.Cookie(TokenCookieName)
+	if err != nil || !cs.isValidToken(cookie.Value) {
+		return ""
+	}
+	return cookie.Value
+}
+
+// ensureToken returns the request's token, issuing a new one in a cookie if
+// the request does not have a valid token
+func (cs *csrf) ensureToken(w http.ResponseWriter, r *http.Request) (string, error) {
+	if token := cs.cookieToken(r); token != "" {
+		return token, nil
+	}
+	token, err := cs.newToken()
+	if err != nil {
+		return "", err
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:  TokenCookieName,
+		Value: token,
+		Path:  cs.opts.CookiePath,
+		// Read by the web UI to send the token in the request header
+		HttpOnly: false,
+		Secure:   cs.opts.Origins.IsTLSRequest(r),
+		SameSite: http.SameSiteStrictMode,
+	})
+	return token, nil
+}
+
+// checkToken verifies that the token in the request's header matches the
+// signed token in its cookie
+func (cs *csrf) checkToken(r *http.Request) error {
+	header := r.Header.Get(TokenHeaderName)
+	if header == "" {
+		return errNoToken
+	}
+	cookie := cs.cookieToken(r)
+	if cookie == "" || subtle.ConstantTimeCompare([]byte(header), []byte(cookie)) != 1 {
+		return errInvalidToken
+	}
+	return nil
+}
+
+// serveToken responds with the request's token, issuing a new one if needed
+func (cs *csrf) serveToken(w http.ResponseWriter, r *http.Request) {
+	token, err := cs.ensureToken(w, r)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(map[string]string{"token": token})
+}
+
+// requestOrigin returns the origin of the page which sent the request, from
+// its Origin header or otherwise its Referer header
+func requestOrigin(r *http.Request) (*url.URL, error) {
+	value := r.Header.Get("Origin")
+	if value == "" || value == "null" {
+		value = r.Header.Get("Referer")
+	}
+	if value == "" {
+		return nil, errNoOrigin
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, errInvalidOrigin
+	}
+	return u, nil
+}
+
+// checkOrigin verifies that the request was sent by a page served from an
+// origin allowed by the policy
+func (cs *csrf) checkOrigin(r *http.Request) error {
+	origin, err := requestOrigin(r)
+	if err != nil {
+		return err
+	}
+	if !cs.opts.Origins.IsAllowed(r, origin) {
+		return errInvalidOrigin
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	"github.com/tektoncd/dashboard/pkg/utils"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// accessReviewTTL is how long the result of an access review is reused
+	// for the same user
+	accessReviewTTL = 30 * time.Second
+	// maxAccessReviews limits the number of cached access reviews
+	maxAccessReviews = 10000
+)
+
+type accessReviewKey struct {
+	user      string
+	gvr       schema.GroupVersionResource
+	namespace string
+}
+
+type accessReview struct {
+	allowed bool
+	expires time.Time
+}
+
+// AccessReviews caches whether users may list resources in a namespace, so
+// that filtering the results of the in-memory indexes doesn't send an access
+// review to the API server for every request
+type AccessReviews struct {
+	mu      sync.Mutex
+	entries map[accessReviewKey]accessReview
+}
+
+// NewAccessReviews returns an empty cache of access reviews
+func NewAccessReviews() *AccessReviews {
+	return &AccessReviews{entries: map[accessReviewKey]accessReview{}}
+}
+
+func (a *AccessReviews) get(key accessReviewKey) (bool, bool) {
+	if a == nil {
+		return false, false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	review, ok := a.entries[key]
+	if !ok || time.Now().After(review.expires) {
+		return false, false
+	}
+	return review.allowed, true
+}
+
+func (a *AccessReviews) set(key accessReviewKey, allowed bool) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := time.Now()
+	if len(a.entries) >= maxAccessReviews {
+		for k, review := range a.entries {
+			if now.After(review.expires) {
+				delete(a.entries, k)
+			}
+		}
+		if len(a.entries) >= maxAccessReviews {
+			clear(a.entries)
+		}
+	}
+	a.entries[key] = accessReview{allowed: allowed, expires: now.Add(accessReviewTTL)}
+}
+
+// identityKey identifies a user by a hash of their identity headers, so that
+// credentials are not kept in memory
+func identityKey(identity http.Header) string {
+	hash := sha256.New()
+	for _, key := range slices.Sorted(maps.Keys(identity)) {
+		for _, value := range identity[key] {
+			hash.Write([]byte(key + ":" + value + "\n"))
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// namespaceFilter returns a function reporting whether the user sending the
+// request may list a resource in a namespace. The in-memory indexes are
+// filled with the Dashboard's service account, so their results are filtered
+// with a SelfSubjectAccessReview sent as the user. Requests without identity
+// headers are sent to the API server as the service account, so may read any
+// namespace the indexes hold.
+func (r Resource) namespaceFilter(request *http.Request) (func(gvr schema.GroupVersionResource, namespace string) bool, error) {
+	identity := utils.IdentityHeaders(request.Header)
+	if len(identity) == 0 {
+		return func(schema.GroupVersionResource, string) bool { return true }, nil
+	}
+	client, err := r.userK8sClient(request)
+	if err != nil {
+		return nil, err
+	}
+	user := identityKey(identity)
+	// Failed reviews are not cached, but are only sent once per request
+	reviewed := map[accessReviewKey]bool{}
+	return func(gvr schema.GroupVersionResource, namespace string) bool {
+		key := accessReviewKey{user: user, gvr: gvr, namespace: namespace}
+		if allowed, ok := reviewed[key]; ok {
+			return allowed
+		}
+		if allowed, ok := r.AccessReviews.get(key); ok {
+			reviewed[key] = allowed
+			return allowed
+		}
+		review, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(request.Context(), &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: namespace,
+					Verb:      "list",
+					Group:     gvr.Group,
+					Resource:  gvr.Resource,
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			logging.Log.Warnf("Error reviewing access to %s in namespace %s: %s", gvr.GroupResource(), namespace, err.Error())
+			reviewed[key] = false
+			return false
+		}
+		reviewed[key] = review.Status.Allowed
+		r.AccessReviews.set(key, review.Status.Allowed)
+		return review.Status.Allowed
+	}, nil
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	"github.com/tektoncd/dashboard/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	k8sclientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// AnnouncementLabel identifies the ConfigMaps in the install namespace
+	// containing an announcement
+	AnnouncementLabel = "dashboard.tekton.dev/announcement"
+	// announcementsHeartbeatInterval is how often a comment is sent on idle announcement streams
+	announcementsHeartbeatInterval = 30 * time.Second
+)
+
+// Announcement is a message displayed to all Dashboard users, or only to
+// those viewing one of its target namespaces
+type Announcement struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+	// Severity is one of info, warning or error
+	Severity   string     `json:"severity"`
+	Start      *time.Time `json:"start,omitempty"`
+	End        *time.Time `json:"end,omitempty"`
+	Namespaces []string   `json:"namespaces,omitempty"`
+}
+
+var announcementSeverities = map[string]int{"error": 0, "warning": 1, "info": 2}
+
+// isActive returns true if the announcement should be displayed at the given time
+func (a Announcement) isActive(now time.Time) bool {
+	return (a.Start == nil || !now.Before(*a.Start)) && (a.End == nil || now.Before(*a.End))
+}
+
+// targets returns true if the announcement should be displayed in the given
+// namespace, an empty namespace matches all announcements
+func (a Announcement) targets(namespace string) bool {
+	return namespace == "" || len(a.Namespaces) == 0 || slices.Contains(a.Namespaces, namespace)
+}
+
+// parseAnnouncementTime sets dst to the time in the given key of the data, if any
+func parseAnnouncementTime(data map[string]string, key string, dst **time.Time) error {
+	value := data[key]
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+	*dst = &t
+	return nil
+}
+
+// parseAnnouncement reads an announcement from the data of a ConfigMap
+func parseAnnouncement(configMap *corev1.ConfigMap) (Announcement, error) {
+	announcement := Announcement{
+		Name:     configMap.Name,
+		Message:  strings.TrimSpace(configMap.Data["message"]),
+		Severity: cmp.Or(configMap.Data["severity"], "info"),
+	}
+	if announcement.Message == "" {
+		return announcement, errors.New("message is required")
+	}
+	if _, ok := announcementSeverities[announcement.Severity]; !ok {
+		return announcement, fmt.Errorf("invalid severity %q, expected info, warning or error", announcement.Severity)
+	}
+
+	if err := parseAnnouncementTime(configMap.Data, "start", &announcement.Start); err != nil {
+		return announcement, err
+	}
+	if err := parseAnnouncementTime(configMap.Data, "end", &announcement.End); err != nil {
+		return announcement, err
+	}
+	if announcement.Start != nil && announcement.End != nil && !announcement.End.After(*announcement.Start) {
+		return announcement, errors.New("end must be after start")
+	}
+	announcement.Namespaces = strings.FieldsFunc(configMap.Data["namespaces"], func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n'
+	})
+	return announcement, nil
+}
+
+// Announcements watches the announcement ConfigMaps in a namespace and
+// notifies subscribers whenever they change
+type Announcements struct {
+	mu            sync.RWMutex
+	announcements []Announcement
+	// changed is closed and replaced each time the announcements change
+	changed chan struct{}
+
+	informer cache.SharedIndexInformer
+}
+
+// NewAnnouncements returns an Announcements watching the labelled ConfigMaps
+// in the given namespace. Call Run to start watching.
+func NewAnnouncements(client k8sclientset.Interface, namespace string) *Announcements {
+	factory := informers.NewSharedInformerFactoryWithOptions(client, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = AnnouncementLabel
+		}),
+	)
+	return &Announcements{
+		changed:  make(chan struct{}),
+		informer: factory.Core().V1().ConfigMaps().Informer(),
+	}
+}
+
+// Run watches the announcement ConfigMaps until the context is done
+func (a *Announcements) Run(ctx context.Context) error {
+	update := func(any) { a.update() }
+	if _, err := a.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    update,
+		UpdateFunc: func(_, _ any) { a.update() },
+		DeleteFunc: update,
+	}); err != nil {
+		return err
+	}
+	a.informer.Run(ctx.Done())
+	return nil
+}
+
+// update rebuilds the announcements from the informer's cache and notifies subscribers
+func (a *Announcements) update() {
+	var announcements []Announcement
+	for _, obj := range a.informer.GetStore().List() {
+		configMap, ok := obj.(*corev1.ConfigMap)
+		if !ok {
+			continue
+		}
+		announcement, err := parseAnnouncement(configMap)
+		if err != nil {
+			logging.Log.Warnf("Ignoring invalid announcement %s/%s: %s", configMap.Namespace, configMap.Name, err.Error())
+			continue
+		}
+		announcements = append(announcements, announcement)
+	}
+	slices.SortFunc(announcements, func(x, y Announcement) int {
+		return cmp.Or(
+			cmp.Compare(announcementSeverities[x.Severity], announcementSeverities[y.Severity]),
+			cmp.Compare(x.Name, y.Name),
+		)
+	})
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.announcements = announcements
+	close(a.changed)
+	a.changed = make(chan struct{})
+}
+
+// get returns the announcements active at the given time for the namespace,
+// the time at which the result will next change due to an announcement
+// starting or ending, and a channel closed when the announcements change
+func (a *Announcements) get(now time.Time, namespace string) ([]Announcement, time.Time, <-chan struct{}) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	active := []Announcement{}
+	var next time.Time
+	for _, announcement := range a.announcements {
+		if !announcement.targets(namespace) {
+			continue
+		}
+		if announcement.isActive(now) {
+			active = append(active, announcement)
+		}
+		for _, t := range []*time.Time{announcement.Start, announcement.End} {
+			if t != nil && t.After(now) && (next.IsZero() || t.Before(next)) {
+				next = *t
+			}
+		}
+	}
+	return active, next, a.changed
+}
+
+// GetAnnouncements returns the active announcements, optionally only those
+// displayed in the namespace given in the query
+func (r Resource) GetAnnouncements(response http.ResponseWriter, request *http.Request) {
+	announcements := []Announcement{}
+	if r.Announcements != nil {
+		announcements, _, _ = r.Announcements.get(time.Now(), request.URL.Query().Get("namespace"))
+	}
+
+	response.Header().Set("Content-Type", "application/json")
+	response.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(response).Encode(announcements); err != nil {
+		logging.Log.Error("Error encoding announcements: ", err)
+	}
+}
+
+// StreamAnnouncements sends the active announcements as an announcements
+// event when the stream starts, and again each time they change
+func (r Resource) StreamAnnouncements(response http.ResponseWriter, request *http.Request) {
+	if r.Announcements == nil {
+		http.Error(response, "announcements are not enabled", http.StatusNotFound)
+		return
+	}
+	namespace := request.URL.Query().Get("namespace")
+
+	ctx, cancel := context.WithCancel(request.Context())
+	var heartbeat sync.WaitGroup
+	// Stop the heartbeat before returning so it doesn't write to the
+	// response once the handler has finished
+	defer heartbeat.Wait()
+	defer cancel()
+
+	events := utils.NewEventWriter(response)
+	heartbeat.Go(func() { events.Heartbeat(ctx, announcementsHeartbeatInterval) })
+
+	var sent string
+	for {
+		announcements, next, changed := r.Announcements.get(time.Now(), namespace)
+		data, err := json.Marshal(announcements)
+		if err != nil {
+			logging.Log.Error("Error encoding announcements: ", err)
+			return
+		}
+		// Changes to announcements targeting other namespaces are not sent
+		if string(data) != sent {
+			if err := events.WriteEvent("", "announcements", string(data)); err != nil {
+				return
+			}
+			sent = string(data)
+		}
+
+		if !waitForAnnouncements(ctx, next, changed) {
+			return
+		}
+	}
+}
+
+// waitForAnnouncements blocks until the announcements change or one starts or
+// ends at the next time, if set. It returns false if the context is done first.
+func waitForAnnouncements(ctx context.Context, next time.Time, changed <-chan struct{}) bool {
+	var expired <-chan time.Time
+	if !next.IsZero() {
+		timer := time.NewTimer(time.Until(next))
+		defer timer.Stop()
+		expired = timer.C
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case <-changed:
+		return true
+	case <-expired:
+		return true
+	}
+}
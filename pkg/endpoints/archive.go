@@ -0,0 +1,230 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	"github.com/tektoncd/dashboard/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sclientset "k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// stepStatus is the subset of a TaskRun step's status needed to locate its logs
+type stepStatus struct {
+	name           string
+	container      string
+	startTime      string
+	completionTime string
+}
+
+// getStepStatuses returns the steps recorded in a TaskRun's status, in order
+func getStepStatuses(taskRun *unstructured.Unstructured) []stepStatus {
+	steps, _, _ := unstructured.NestedSlice(taskRun.Object, "status", "steps")
+	var statuses []stepStatus
+	for _, s := range steps {
+		step, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		status := stepStatus{}
+		status.name, _, _ = unstructured.NestedString(step, "name")
+		status.container, _, _ = unstructured.NestedString(step, "container")
+		if status.container == "" {
+			status.container = stepContainerPrefix + status.name
+		}
+		if startTime, found, _ := unstructured.NestedString(step, "running", "startedAt"); found {
+			status.startTime = startTime
+		}
+		if startTime, found, _ := unstructured.NestedString(step, "terminated", "startedAt"); found {
+			status.startTime = startTime
+		}
+		status.completionTime, _, _ = unstructured.NestedString(step, "terminated", "finishedAt")
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// openStepLogs returns the logs of a step, from the cluster if the pod is still
+// available, or from the external logs provider otherwise
+func (r Resource) openStepLogs(ctx context.Context, client k8sclientset.Interface, namespace, pod string, step stepStatus) (io.ReadCloser, error) {
+	stream, err := client.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{
+		Container: step.container,
+	}).Stream(ctx)
+	if err == nil || r.Options.ExternalLogsURL == "" {
+		return stream, err
+	}
+
+	query := url.Values{}
+	if step.startTime != "" {
+		query.Set("startTime", step.startTime)
+	}
+	if step.completionTime != "" {
+		query.Set("completionTime", step.completionTime)
+	}
+	logsURL := fmt.Sprintf("%s/%s/%s/%s", r.Options.ExternalLogsURL, url.PathEscape(namespace), url.PathEscape(pod), url.PathEscape(step.container))
+	if len(query) > 0 {
+		logsURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.getExternalLogsClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("external logs provider responded with status %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// writeZipYAML adds a resource to the archive as YAML, omitting its managed fields
+func writeZipYAML(archive *zip.Writer, name string, obj *unstructured.Unstructured) error {
+	obj = obj.DeepCopy()
+	obj.SetManagedFields(nil)
+	content, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return err
+	}
+	entry, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	_, err = entry.Write(content)
+	return err
+}
+
+// writeZipStepLogs adds the logs of a step to the archive. If the logs cannot
+// be retrieved an error file is added in their place.
+func (r Resource) writeZipStepLogs(ctx context.Context, client k8sclientset.Interface, archive *zip.Writer, dir, namespace, pod string, step stepStatus) error {
+	logs, logsErr := r.openStepLogs(ctx, client, namespace, pod, step)
+	if logsErr != nil {
+		logging.Log.Warnf("Unable to retrieve logs for %s/%s/%s: %s", namespace, pod, step.container, logsErr.Error())
+		entry, err := archive.CreateHeader(&zip.FileHeader{Name: path.Join(dir, step.name+".error.txt"), Method: zip.Deflate, Modified: time.Now()})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(entry, "Unable to retrieve logs for step %s: %s\n", step.name, logsErr)
+		return err
+	}
+	defer logs.Close()
+
+	entry, err := archive.CreateHeader(&zip.FileHeader{Name: path.Join(dir, step.name+".log"), Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, logs)
+	return err
+}
+
+// DownloadPipelineRunLogs streams a zip archive containing the PipelineRun and
+// its TaskRuns as YAML, along with the logs of every step organised by task
+func (r Resource) DownloadPipelineRunLogs(response http.ResponseWriter, request *http.Request) {
+	ctx := request.Context()
+	namespace := request.PathValue("namespace")
+	name := request.PathValue("name")
+	if !r.Options.IsNamespaceInScope(namespace) {
+		http.Error(response, "namespace not available", http.StatusForbidden)
+		return
+	}
+
+	// The runs and logs are read as the user, so they can only download
+	// what they could read through the Kubernetes API proxy
+	dynamicClient, err := r.userDynamicClient(request)
+	if err != nil {
+		utils.RespondError(response, err, http.StatusInternalServerError)
+		return
+	}
+	k8sClient, err := r.userK8sClient(request)
+	if err != nil {
+		utils.RespondError(response, err, http.StatusInternalServerError)
+		return
+	}
+
+	pipelineRun, err := dynamicClient.Resource(pipelineRunsGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		utils.RespondError(response, err, utils.StatusCodeForError(err))
+		return
+	}
+	taskRuns, err := dynamicClient.Resource(taskRunsGVR).Namespace(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: pipelineRunLabel + "=" + name,
+	})
+	if err != nil {
+		utils.RespondError(response, err, utils.StatusCodeForError(err))
+		return
+	}
+	sort.Slice(taskRuns.Items, func(i, j int) bool {
+		first, second := taskRuns.Items[i].GetCreationTimestamp(), taskRuns.Items[j].GetCreationTimestamp()
+		return first.Before(&second)
+	})
+
+	response.Header().Set("Content-Type", "application/zip")
+	response.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+"-logs.zip"))
+	response.WriteHeader(http.StatusOK)
+
+	archive := zip.NewWriter(response)
+	defer func() {
+		if err := archive.Close(); err != nil {
+			logging.Log.Errorf("Error finalising logs archive for PipelineRun %s/%s: %s", namespace, name, err.Error())
+		}
+	}()
+
+	if err := writeZipYAML(archive, "pipelinerun.yaml", pipelineRun); err != nil {
+		logging.Log.Errorf("Error writing logs archive for PipelineRun %s/%s: %s", namespace, name, err.Error())
+		return
+	}
+
+	dirs := map[string]bool{}
+	for i := range taskRuns.Items {
+		taskRun := &taskRuns.Items[i]
+		// Tasks using a matrix create several TaskRuns for the same pipeline task
+		dir := taskRun.GetLabels()[pipelineTaskLabel]
+		if dir == "" || dirs[dir] {
+			dir = taskRun.GetName()
+		}
+		dirs[dir] = true
+
+		if err := writeZipYAML(archive, path.Join(dir, "taskrun.yaml"), taskRun); err != nil {
+			logging.Log.Errorf("Error writing logs archive for PipelineRun %s/%s: %s", namespace, name, err.Error())
+			return
+		}
+
+		pod, _, _ := unstructured.NestedString(taskRun.Object, "status", "podName")
+		if pod == "" {
+			continue
+		}
+		for _, step := range getStepStatuses(taskRun) {
+			if err := r.writeZipStepLogs(ctx, k8sClient, archive, dir, namespace, pod, step); err != nil {
+				logging.Log.Errorf("Error writing logs archive for PipelineRun %s/%s: %s", namespace, name, err.Error())
+				return
+			}
+		}
+	}
+}
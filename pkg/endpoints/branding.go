@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// brandingFile is the name of the branding configuration in the branding directory
+	brandingFile = "branding.yaml"
+	// BrandingAssetsPath is where the assets in the branding directory are served
+	BrandingAssetsPath = "/v1/branding/"
+)
+
+var colorPattern = regexp.MustCompile(`^#([[:xdigit:]]{3,4}|[[:xdigit:]]{6}|[[:xdigit:]]{8})$`)
+
+// Branding customises the appearance of the Dashboard
+type Branding struct {
+	Title       string          `json:"title"`
+	LogoURL     string          `json:"logoURL,omitempty"`
+	FaviconURL  string          `json:"faviconURL,omitempty"`
+	AccentColor string          `json:"accentColor,omitempty"`
+	Links       []BrandingLink  `json:"links,omitempty"`
+	Banner      *BrandingBanner `json:"banner,omitempty"`
+}
+
+// BrandingLink is a link displayed in the Dashboard's header menu
+type BrandingLink struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// BrandingBanner is a message displayed at the top of every page
+type BrandingBanner struct {
+	Message string `json:"message"`
+	// Kind is one of info, warning or error
+	Kind string `json:"kind,omitempty"`
+}
+
+// DefaultBranding returns the built-in Tekton branding
+func DefaultBranding() Branding {
+	return Branding{Title: "Tekton Dashboard"}
+}
+
+// brandingConfig is the content of the branding configuration file. Logo and
+// favicon are either the name of a file in the branding directory or a URL.
+type brandingConfig struct {
+	Title       string          `json:"title"`
+	Logo        string          `json:"logo"`
+	Favicon     string          `json:"favicon"`
+	AccentColor string          `json:"accentColor"`
+	Links       []BrandingLink  `json:"links"`
+	Banner      *BrandingBanner `json:"banner"`
+}
+
+func isHTTPURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// isBrandingAssetName returns true for the name of a file directly inside the
+// branding directory, excluding hidden files such as those created when
+// mounting a ConfigMap
+func isBrandingAssetName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, `/\`) && !strings.HasPrefix(name, ".")
+}
+
+// brandingAssetURL returns the URL of a branding asset, which may be a file
+// in the branding directory or an external URL. Files are referenced relative
+// to the document so they resolve under a base path or kubectl proxy.
+func brandingAssetURL(dir, value string) (string, error) {
+	if value == "" || isHTTPURL(value) {
+		return value, nil
+	}
+	if !isBrandingAssetName(value) {
+		return "", fmt.Errorf("%q must be a URL or the name of a file in the branding directory", value)
+	}
+	if _, err := os.Stat(filepath.Join(dir, value)); err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(BrandingAssetsPath, "/") + url.PathEscape(value), nil
+}
+
+// LoadBranding reads the branding configuration from the given directory,
+// falling back to the default branding for any value not provided
+func LoadBranding(dir string) (Branding, error) {
+	branding := DefaultBranding()
+	if dir == "" {
+		return branding, nil
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, brandingFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return branding, nil
+	}
+	if err != nil {
+		return branding, err
+	}
+
+	var config brandingConfig
+	if err := yaml.UnmarshalStrict(content, &config); err != nil {
+		return branding, fmt.Errorf("error parsing %s: %w", brandingFile, err)
+	}
+
+	if config.Title != "" {
+		branding.Title = config.Title
+	}
+	if branding.LogoURL, err = brandingAssetURL(dir, config.Logo); err != nil {
+		return branding, fmt.Errorf("invalid logo: %w", err)
+	}
+	if branding.FaviconURL, err = brandingAssetURL(dir, config.Favicon); err != nil {
+		return branding, fmt.Errorf("invalid favicon: %w", err)
+	}
+	if config.AccentColor != "" && !colorPattern.MatchString(config.AccentColor) {
+		return branding, fmt.Errorf("invalid accentColor %q, expected a hex color such as #0f62fe", config.AccentColor)
+	}
+	branding.AccentColor = config.AccentColor
+	for _, link := range config.Links {
+		if link.Label == "" || !isHTTPURL(link.URL) {
+			return branding, fmt.Errorf("invalid link %q, a label and http(s) URL are required", link.Label)
+		}
+	}
+	branding.Links = config.Links
+	if config.Banner != nil && config.Banner.Message != "" {
+		switch config.Banner.Kind {
+		case "":
+			config.Banner.Kind = "info"
+		case "info", "warning", "error":
+		default:
+			return branding, fmt.Errorf("invalid banner kind %q, expected info, warning or error", config.Banner.Kind)
+		}
+		branding.Banner = config.Banner
+	}
+
+	return branding, nil
+}
+
+// GetBrandingAsset serves a file from the branding directory
+func (r Resource) GetBrandingAsset(response http.ResponseWriter, request *http.Request) {
+	name := request.PathValue("name")
+	if !isBrandingAssetName(name) {
+		http.NotFound(response, request)
+		return
+	}
+
+	// Revalidate so updates to the branding are picked up
+	response.Header().Set("Cache-Control", "no-cache")
+	// Assets such as SVG images must not run scripts when opened directly.
+	// Added as a separate policy so any existing policy is still enforced.
+	response.Header().Add("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox;")
+	http.ServeFileFS(response, request, os.DirFS(r.Options.BrandingDir), name)
+}
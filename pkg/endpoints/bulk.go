@@ -0,0 +1,309 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	"github.com/tektoncd/dashboard/pkg/runs"
+	"github.com/tektoncd/dashboard/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// Bulk actions
+const (
+	BulkActionCancel         = "cancel"
+	BulkActionGracefulCancel = "gracefulCancel"
+	BulkActionGracefulStop   = "gracefulStop"
+	BulkActionDelete         = "delete"
+	BulkActionPause          = "pause"
+	BulkActionResume         = "resume"
+)
+
+// Results of a bulk action on a run
+const (
+	BulkResultSucceeded = "succeeded"
+	BulkResultSkipped   = "skipped"
+	BulkResultFailed    = "failed"
+)
+
+const (
+	// bulkConcurrency is the number of runs updated at once
+	bulkConcurrency = 5
+	// maxBulkItems is the maximum number of runs updated by a request
+	maxBulkItems = 500
+	// maxBulkRequestSize limits the size of the requests we accept
+	maxBulkRequestSize = 256 * 1024
+)
+
+// Values of spec.status requesting Tekton to cancel, stop, or not start a run
+const (
+	pipelineRunCancelled         = "Cancelled"
+	pipelineRunCancelledFinally  = "CancelledRunFinally"
+	pipelineRunStoppedRunFinally = "StoppedRunFinally"
+	pipelineRunPending           = "PipelineRunPending"
+	taskRunCancelled             = "TaskRunCancelled"
+)
+
+// BulkRequest selects the runs to apply an action to, either by name or with
+// a label selector
+type BulkRequest struct {
+	// Kind is pipelineruns or taskruns
+	Kind          string   `json:"kind"`
+	Namespace     string   `json:"namespace"`
+	Names         []string `json:"names,omitempty"`
+	LabelSelector string   `json:"labelSelector,omitempty"`
+	Action        string   `json:"action"`
+}
+
+// BulkResult is the result of the action on one run
+type BulkResult struct {
+	Name   string `json:"name"`
+	Result string `json:"result"`
+	// Message describes why the run was skipped or the action failed
+	Message string `json:"message,omitempty"`
+	// Code is the status code of the failed request to the API server
+	Code int `json:"code,omitempty"`
+}
+
+// bulkActions lists the actions supported for each kind of run. Graceful
+// cancellation and pending runs are only supported for PipelineRuns.
+var bulkActions = map[runs.Kind][]string{
+	runs.KindPipelineRun: {BulkActionCancel, BulkActionGracefulCancel, BulkActionGracefulStop, BulkActionDelete, BulkActionPause, BulkActionResume},
+	runs.KindTaskRun:     {BulkActionCancel, BulkActionDelete},
+}
+
+var runGVRs = map[runs.Kind]schema.GroupVersionResource{
+	runs.KindPipelineRun: pipelineRunsGVR,
+	runs.KindTaskRun:     taskRunsGVR,
+}
+
+// validate returns the kind of run, or an error if the request is invalid
+func (b BulkRequest) validate() (runs.Kind, error) {
+	kind, err := runs.ParseKind(b.Kind)
+	if err != nil {
+		return "", err
+	}
+	if b.Namespace == "" {
+		return "", errors.New("namespace is required")
+	}
+	if !slices.Contains(bulkActions[kind], b.Action) {
+		return "", fmt.Errorf("invalid action %q for %s, expected one of %v", b.Action, kind, bulkActions[kind])
+	}
+	if (len(b.Names) == 0) == (b.LabelSelector == "") {
+		return "", errors.New("exactly one of names or labelSelector is required")
+	}
+	if len(b.Names) > maxBulkItems {
+		return "", fmt.Errorf("at most %d runs can be updated at once", maxBulkItems)
+	}
+	if slices.Contains(b.Names, "") {
+		return "", errors.New("names must not be empty")
+	}
+	return kind, nil
+}
+
+// cancelStatuses are the values of spec.status cancelling a PipelineRun
+var cancelStatuses = map[string]string{
+	BulkActionCancel:         pipelineRunCancelled,
+	BulkActionGracefulCancel: pipelineRunCancelledFinally,
+	BulkActionGracefulStop:   pipelineRunStoppedRunFinally,
+}
+
+// bulkPatch returns the patch applying an action to a run, or a message
+// explaining why the run is skipped
+func bulkPatch(kind runs.Kind, action string, run *unstructured.Unstructured) ([]byte, string) {
+	specStatus, _, _ := unstructured.NestedString(run.Object, "spec", "status")
+	status := runs.Summarize(kind, run).Status
+	completed := status != runs.StatusPending && status != runs.StatusRunning
+
+	var value any
+	switch action {
+	case BulkActionCancel, BulkActionGracefulCancel, BulkActionGracefulStop:
+		if completed {
+			return nil, "the run has completed"
+		}
+		cancelled := cancelStatuses[action]
+		if kind == runs.KindTaskRun {
+			cancelled = taskRunCancelled
+		}
+		if specStatus == cancelled {
+			return nil, "the run is already " + specStatus
+		}
+		value = cancelled
+	case BulkActionPause:
+		// Tekton only allows runs to be made pending before they start
+		if status != runs.StatusPending || specStatus != "" {
+			return nil, "only runs which have not started can be paused"
+		}
+		value = pipelineRunPending
+	case BulkActionResume:
+		if specStatus != pipelineRunPending {
+			return nil, "the run is not pending"
+		}
+		// A null value removes the field in a merge patch
+		value = nil
+	}
+	patch, _ := json.Marshal(map[string]any{"spec": map[string]any{"status": value}})
+	return patch, ""
+}
+
+// bulkItem is a run to update, fetched by the worker if selected by name
+type bulkItem struct {
+	name string
+	run  *unstructured.Unstructured
+}
+
+// apply applies the action to a run and returns the result
+func (b BulkRequest) apply(ctx context.Context, client dynamic.ResourceInterface, kind runs.Kind, item bulkItem) BulkResult {
+	result := BulkResult{Name: item.name, Result: BulkResultSucceeded}
+	fail := func(err error) BulkResult {
+		result.Result = BulkResultFailed
+		result.Message = err.Error()
+		result.Code = utils.StatusCodeForError(err)
+		return result
+	}
+
+	if b.Action == BulkActionDelete {
+		if err := client.Delete(ctx, item.name, metav1.DeleteOptions{}); err != nil {
+			return fail(err)
+		}
+		return result
+	}
+
+	run := item.run
+	if run == nil {
+		var err error
+		if run, err = client.Get(ctx, item.name, metav1.GetOptions{}); err != nil {
+			return fail(err)
+		}
+	}
+	patch, skipped := bulkPatch(kind, b.Action, run)
+	if skipped != "" {
+		result.Result = BulkResultSkipped
+		result.Message = skipped
+		return result
+	}
+	if _, err := client.Patch(ctx, item.name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fail(err)
+	}
+	return result
+}
+
+// BulkUpdateRuns cancels, deletes, pauses or resumes the PipelineRuns or
+// TaskRuns selected by the request, as the user sending the request. The
+// result of each run is streamed as a line of JSON as soon as it is known.
+func (r Resource) BulkUpdateRuns(response http.ResponseWriter, request *http.Request) {
+	ctx := request.Context()
+	request.Body = http.MaxBytesReader(response, request.Body, maxBulkRequestSize)
+	var bulk BulkRequest
+	if err := json.NewDecoder(request.Body).Decode(&bulk); err != nil {
+		statusCode := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			statusCode = http.StatusRequestEntityTooLarge
+		}
+		utils.RespondError(response, err, statusCode)
+		return
+	}
+	kind, err := bulk.validate()
+	if err != nil {
+		utils.RespondError(response, err, http.StatusBadRequest)
+		return
+	}
+	if !r.Options.IsNamespaceInScope(bulk.Namespace) {
+		http.Error(response, "namespace not available", http.StatusForbidden)
+		return
+	}
+
+	userClient, err := r.userDynamicClient(request)
+	if err != nil {
+		utils.RespondError(response, err, http.StatusInternalServerError)
+		return
+	}
+	client := userClient.Resource(runGVRs[kind]).Namespace(bulk.Namespace)
+
+	var items []bulkItem
+	if bulk.LabelSelector != "" {
+		if _, err := labels.Parse(bulk.LabelSelector); err != nil {
+			utils.RespondError(response, fmt.Errorf("invalid labelSelector: %w", err), http.StatusBadRequest)
+			return
+		}
+		list, err := client.List(ctx, metav1.ListOptions{LabelSelector: bulk.LabelSelector})
+		if err != nil {
+			utils.RespondError(response, err, utils.StatusCodeForError(err))
+			return
+		}
+		if len(list.Items) > maxBulkItems {
+			utils.RespondError(response, fmt.Errorf("the labelSelector matches %d runs, at most %d runs can be updated at once", len(list.Items), maxBulkItems), http.StatusBadRequest)
+			return
+		}
+		for i := range list.Items {
+			items = append(items, bulkItem{name: list.Items[i].GetName(), run: &list.Items[i]})
+		}
+	} else {
+		names := slices.Clone(bulk.Names)
+		slices.Sort(names)
+		for _, name := range slices.Compact(names) {
+			items = append(items, bulkItem{name: name})
+		}
+	}
+	logging.Log.Infof("Applying %s to %d %ss in %s", bulk.Action, len(items), kind, bulk.Namespace)
+
+	queue := make(chan bulkItem)
+	results := make(chan BulkResult)
+	var workers sync.WaitGroup
+	for range min(bulkConcurrency, len(items)) {
+		workers.Go(func() {
+			for item := range queue {
+				results <- bulk.apply(ctx, client, kind, item)
+			}
+		})
+	}
+	go func() {
+		defer close(queue)
+		for _, item := range items {
+			select {
+			case queue <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		workers.Wait()
+		close(results)
+	}()
+
+	response.Header().Set("Content-Type", "application/x-ndjson")
+	response.Header().Set("Cache-Control", "no-cache")
+	response.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(utils.MakeFlushWriter(response))
+	for result := range results {
+		if err := encoder.Encode(result); err != nil {
+			logging.Log.Error("Failed encoding bulk result")
+		}
+	}
+}
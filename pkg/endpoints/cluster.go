@@ -1,5 +1,5 @@
 /*
-Copyright 2019-2024 The Tekton Authors
+Copyright 2019-2026 The Tekton Authors
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
@@ -22,6 +22,9 @@ import (
 
 // Properties : properties we want to be able to retrieve via REST
 type Properties struct {
+	BasePath           string   `json:"basePath,omitempty"`
+	Branding           Branding `json:"branding"`
+	Cluster            string   `json:"cluster,omitempty"`
 	DashboardNamespace string   `json:"dashboardNamespace"`
 	DashboardVersion   string   `json:"dashboardVersion"`
 	DefaultNamespace   string   `json:"defaultNamespace,omitempty"`
@@ -40,12 +43,20 @@ type Properties struct {
 // the version of the Tekton Dashboard, the version of Tekton Pipelines,
 // when one's in read-only mode and Tekton Triggers version (if Installed)
 func (r Resource) GetProperties(response http.ResponseWriter, _ *http.Request) {
+	writeProperties(response, r.getProperties(r))
+}
+
+// getProperties returns the properties of the Dashboard, with the versions of
+// Tekton Pipelines and Triggers installed in the cluster accessed by tekton
+func (r Resource) getProperties(tekton Resource) Properties {
 	pipelineNamespace := r.Options.GetPipelinesNamespace()
 	triggersNamespace := r.Options.GetTriggersNamespace()
 	dashboardVersion := r.GetDashboardVersion()
-	pipelineVersion := getPipelineVersion(r, pipelineNamespace)
+	pipelineVersion := getPipelineVersion(tekton, pipelineNamespace)
 
 	properties := Properties{
+		BasePath:           r.Options.BasePath,
+		Branding:           r.Options.Branding,
 		DashboardNamespace: r.Options.InstallNamespace,
 		DashboardVersion:   dashboardVersion,
 		DefaultNamespace:   r.Options.DefaultNamespace,
@@ -61,14 +72,18 @@ func (r Resource) GetProperties(response http.ResponseWriter, _ *http.Request) {
 		properties.ExternalLogsURL = "/v1/logs-proxy"
 	}
 
-	isTriggersInstalled := IsTriggersInstalled(r, triggersNamespace)
+	isTriggersInstalled := IsTriggersInstalled(tekton, triggersNamespace)
 
 	if isTriggersInstalled {
-		triggersVersion := getTriggersVersion(r, triggersNamespace)
+		triggersVersion := getTriggersVersion(tekton, triggersNamespace)
 		properties.TriggersNamespace = triggersNamespace
 		properties.TriggersVersion = triggersVersion
 	}
 
+	return properties
+}
+
+func writeProperties(response http.ResponseWriter, properties Properties) {
 	response.Header().Set("Content-Type", "application/json")
 	response.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
 	response.Header().Set("Pragma", "no-cache")
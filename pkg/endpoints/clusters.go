@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/tektoncd/dashboard/pkg/clusters"
+	"github.com/tektoncd/dashboard/pkg/logging"
+)
+
+// getCluster returns the cluster named in the request path, responding with
+// a 404 if it does not exist
+func (r Resource) getCluster(response http.ResponseWriter, request *http.Request) (*clusters.Cluster, bool) {
+	cluster, ok := r.Clusters.Get(request.PathValue("cluster"))
+	if !ok {
+		http.Error(response, "cluster not found", http.StatusNotFound)
+	}
+	return cluster, ok
+}
+
+// GetClusters lists the clusters managed by the Dashboard
+func (r Resource) GetClusters(response http.ResponseWriter, _ *http.Request) {
+	response.Header().Set("Content-Type", "application/json")
+	response.Header().Set("Cache-Control", "no-cache")
+	if err := json.NewEncoder(response).Encode(r.Clusters.List()); err != nil {
+		logging.Log.Error("Failed encoding clusters")
+	}
+}
+
+// GetClusterProperties returns the properties of the Dashboard with the
+// versions of Tekton installed in the requested cluster
+func (r Resource) GetClusterProperties(response http.ResponseWriter, request *http.Request) {
+	cluster, ok := r.getCluster(response, request)
+	if !ok {
+		return
+	}
+	tekton := r
+	tekton.Config = cluster.Config
+	tekton.K8sClient = cluster.Client
+
+	properties := r.getProperties(tekton)
+	properties.Cluster = cluster.Name
+	writeProperties(response, properties)
+}
+
+// GetClusterHealth checks the readiness of the requested cluster's API
+// server, responding with a 503 if it is not ready
+func (r Resource) GetClusterHealth(response http.ResponseWriter, request *http.Request) {
+	cluster, ok := r.getCluster(response, request)
+	if !ok {
+		return
+	}
+	health := cluster.CheckHealth(request.Context())
+
+	response.Header().Set("Content-Type", "application/json")
+	response.Header().Set("Cache-Control", "no-cache")
+	if !health.Healthy {
+		response.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(response).Encode(health); err != nil {
+		logging.Log.Error("Failed encoding cluster health")
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	"github.com/tektoncd/dashboard/pkg/utils"
+)
+
+// maxCSPReportSize limits the size of violation reports we accept
+const maxCSPReportSize = 64 * 1024
+
+// cspViolation contains the fields of a violation report we log. The report-uri
+// directive sends these with hyphenated names while the Reporting API uses camel case.
+type cspViolation struct {
+	DocumentURI        string `json:"document-uri"`
+	BlockedURI         string `json:"blocked-uri"`
+	ViolatedDirective  string `json:"violated-directive"`
+	EffectiveDirective string `json:"effective-directive"`
+	SourceFile         string `json:"source-file"`
+	LineNumber         int    `json:"line-number"`
+
+	DocumentURL                string `json:"documentURL"`
+	BlockedURL                 string `json:"blockedURL"`
+	EffectiveDirectiveReported string `json:"effectiveDirective"`
+	SourceFileReported         string `json:"sourceFile"`
+	LineNumberReported         int    `json:"lineNumber"`
+}
+
+func (v cspViolation) log() {
+	logging.Log.Warnw("Content-Security-Policy violation",
+		"documentURI", firstNonEmpty(v.DocumentURI, v.DocumentURL),
+		"blockedURI", firstNonEmpty(v.BlockedURI, v.BlockedURL),
+		"directive", firstNonEmpty(v.EffectiveDirective, v.EffectiveDirectiveReported, v.ViolatedDirective),
+		"sourceFile", firstNonEmpty(v.SourceFile, v.SourceFileReported),
+		"lineNumber", max(v.LineNumber, v.LineNumberReported),
+	)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// ReportCSPViolation logs Content-Security-Policy violation reports sent by
+// browsers, in either the report-uri or Reporting API format
+func (r Resource) ReportCSPViolation(response http.ResponseWriter, request *http.Request) {
+	request.Body = http.MaxBytesReader(response, request.Body, maxCSPReportSize)
+
+	mediaType, _, _ := mime.ParseMediaType(request.Header.Get("Content-Type"))
+
+	var err error
+	switch mediaType {
+	case "application/reports+json":
+		var reports []struct {
+			Type string       `json:"type"`
+			Body cspViolation `json:"body"`
+		}
+		if err = json.NewDecoder(request.Body).Decode(&reports); err == nil {
+			for _, report := range reports {
+				if report.Type == "csp-violation" {
+					report.Body.log()
+				}
+			}
+		}
+	default:
+		var report struct {
+			Report cspViolation `json:"csp-report"`
+		}
+		if err = json.NewDecoder(request.Body).Decode(&report); err == nil {
+			report.Report.log()
+		}
+	}
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			statusCode = http.StatusRequestEntityTooLarge
+		}
+		utils.RespondError(response, err, statusCode)
+		return
+	}
+
+	response.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,305 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	"github.com/tektoncd/dashboard/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// Export formats
+const (
+	exportFormatYAML = "yaml"
+	exportFormatJSON = "json"
+)
+
+// coreGroup identifies the core API group in export paths, as the group of
+// core resources is empty
+const coreGroup = "core"
+
+// maxExportItems is the maximum number of resources exported at once
+const maxExportItems = 500
+
+// runtimeLabelPrefixes are the prefixes of labels added by Tekton to the
+// resources it creates
+var runtimeLabelPrefixes = []string{"tekton.dev/", "triggers.tekton.dev/"}
+
+// runtimeAnnotations are the annotations added by tools
+var runtimeAnnotations = map[string]bool{"kubectl.kubernetes.io/last-applied-configuration": true}
+
+// runtimeAnnotationPrefixes are the prefixes of annotations added by
+// controllers, e.g. tekton.dev/v1beta1Resources. Other tekton.dev annotations,
+// such as tekton.dev/displayName, are set by authors.
+var runtimeAnnotationPrefixes = []string{"tekton.dev/v1beta1"}
+
+// exportedMetadata are the metadata fields kept on export, other fields such as
+// uid, resourceVersion and managedFields are set by the API server
+var exportedMetadata = []string{"name", "generateName", "namespace", "labels", "annotations"}
+
+// cleanForExport returns a copy of a resource without the fields set at
+// runtime by the API server and controllers, so that it can be applied again
+func cleanForExport(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	cleaned := obj.DeepCopy()
+	metadata, _, _ := unstructured.NestedMap(cleaned.Object, "metadata")
+	for key := range metadata {
+		if !slices.Contains(exportedMetadata, key) {
+			delete(metadata, key)
+		}
+	}
+	cleaned.Object["metadata"] = metadata
+	delete(cleaned.Object, "status")
+
+	labels := cleaned.GetLabels()
+	for key := range labels {
+		if slices.ContainsFunc(runtimeLabelPrefixes, func(prefix string) bool { return strings.HasPrefix(key, prefix) }) {
+			delete(labels, key)
+		}
+	}
+	cleaned.SetLabels(labels)
+	annotations := cleaned.GetAnnotations()
+	for key := range annotations {
+		if runtimeAnnotations[key] || slices.ContainsFunc(runtimeAnnotationPrefixes, func(prefix string) bool { return strings.HasPrefix(key, prefix) }) {
+			delete(annotations, key)
+		}
+	}
+	cleaned.SetAnnotations(annotations)
+
+	// A run exported while cancelled or pending would be created in that state
+	if cleaned.GetAPIVersion() == pipelineRunsGVR.GroupVersion().String() || cleaned.GetAPIVersion() == taskRunsGVR.GroupVersion().String() {
+		unstructured.RemoveNestedField(cleaned.Object, "spec", "status")
+	}
+	return cleaned
+}
+
+// exportPipeline splits a PipelineRun into a standalone Pipeline, from its
+// embedded spec or the spec resolved by Tekton, and a PipelineRun referencing
+// the Pipeline
+func exportPipeline(run *unstructured.Unstructured, name string) ([]*unstructured.Unstructured, error) {
+	spec, found, _ := unstructured.NestedMap(run.Object, "spec", "pipelineSpec")
+	if !found {
+		spec, found, _ = unstructured.NestedMap(run.Object, "status", "pipelineSpec")
+	}
+	if !found {
+		return nil, fmt.Errorf("PipelineRun %s has no Pipeline spec, it may not have started yet", run.GetName())
+	}
+	if name == "" {
+		name = run.GetLabels()["tekton.dev/pipeline"]
+	}
+	if name == "" {
+		name = run.GetName()
+	}
+
+	pipeline := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": pipelinesGVR.GroupVersion().String(),
+		"kind":       "Pipeline",
+		"spec":       spec,
+	}}
+	pipeline.SetName(name)
+	pipeline.SetNamespace(run.GetNamespace())
+
+	cleanedRun := cleanForExport(run)
+	unstructured.RemoveNestedField(cleanedRun.Object, "spec", "pipelineSpec")
+	if err := unstructured.SetNestedMap(cleanedRun.Object, map[string]any{"name": name}, "spec", "pipelineRef"); err != nil {
+		return nil, err
+	}
+	return []*unstructured.Unstructured{pipeline, cleanedRun}, nil
+}
+
+// findPipeline returns the exported Pipeline with the given name, or nil
+func findPipeline(exported []*unstructured.Unstructured, name string) *unstructured.Unstructured {
+	for _, obj := range exported {
+		if obj.GetKind() == "Pipeline" && obj.GetName() == name {
+			return obj
+		}
+	}
+	return nil
+}
+
+// writeExport writes resources in the requested format. Several resources are
+// written as a multi-document YAML file or a JSON List.
+func writeExport(response http.ResponseWriter, format string, objs []*unstructured.Unstructured) {
+	var body bytes.Buffer
+	switch format {
+	case exportFormatJSON:
+		response.Header().Set("Content-Type", "application/json")
+		var value any
+		if len(objs) == 1 {
+			value = objs[0].Object
+		} else {
+			items := make([]any, 0, len(objs))
+			for _, obj := range objs {
+				items = append(items, obj.Object)
+			}
+			value = map[string]any{"apiVersion": "v1", "kind": "List", "items": items}
+		}
+		encoder := json.NewEncoder(&body)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(value); err != nil {
+			utils.RespondError(response, err, http.StatusInternalServerError)
+			return
+		}
+	default:
+		response.Header().Set("Content-Type", "application/yaml")
+		for i, obj := range objs {
+			data, err := yaml.Marshal(obj.Object)
+			if err != nil {
+				utils.RespondError(response, err, http.StatusInternalServerError)
+				return
+			}
+			if i > 0 {
+				body.WriteString("---\n")
+			}
+			body.Write(data)
+		}
+	}
+	response.Header().Set("Cache-Control", "no-cache")
+	if _, err := response.Write(body.Bytes()); err != nil {
+		logging.Log.Error("Failed writing export")
+	}
+}
+
+// ExportResources responds with one or more resources, without their status
+// and the metadata set at runtime, in YAML or JSON. A single resource is
+// exported when the path includes its name, otherwise the resources with the
+// given names or matching the label selector.
+func (r Resource) ExportResources(response http.ResponseWriter, request *http.Request) {
+	ctx := request.Context()
+	params := request.URL.Query()
+	gvr := schema.GroupVersionResource{
+		Group:    request.PathValue("group"),
+		Version:  request.PathValue("version"),
+		Resource: request.PathValue("resource"),
+	}
+	if gvr.Group == coreGroup {
+		gvr.Group = ""
+	}
+	namespace := request.PathValue("namespace")
+	name := request.PathValue("name")
+
+	format := params.Get("format")
+	switch format {
+	case "":
+		format = exportFormatYAML
+	case exportFormatYAML, exportFormatJSON:
+	default:
+		utils.RespondError(response, fmt.Errorf("invalid format %q, expected %s or %s", format, exportFormatYAML, exportFormatJSON), http.StatusBadRequest)
+		return
+	}
+	asPipeline := params.Get("pipeline") == "true"
+	if asPipeline && gvr != pipelineRunsGVR {
+		utils.RespondError(response, fmt.Errorf("only %s can be exported as a Pipeline", pipelineRunsGVR.GroupResource()), http.StatusBadRequest)
+		return
+	}
+	// Secrets may be readable by the Dashboard's service account but not by the user
+	if gvr.Group == "" && gvr.Resource == "secrets" {
+		http.Error(response, "secrets cannot be exported", http.StatusForbidden)
+		return
+	}
+	if !r.Options.IsNamespaceInScope(namespace) {
+		http.Error(response, "namespace not available", http.StatusForbidden)
+		return
+	}
+
+	userClient, err := r.userDynamicClient(request)
+	if err != nil {
+		utils.RespondError(response, err, http.StatusInternalServerError)
+		return
+	}
+	client := userClient.Resource(gvr).Namespace(namespace)
+
+	var objs []*unstructured.Unstructured
+	names := strings.FieldsFunc(params.Get("names"), func(c rune) bool { return c == ',' })
+	switch {
+	case name != "":
+		names = []string{name}
+	case len(names) == 0 && params.Get("labelSelector") == "":
+		utils.RespondError(response, errors.New("names or labelSelector is required"), http.StatusBadRequest)
+		return
+	case len(names) == 0:
+		list, err := client.List(ctx, metav1.ListOptions{LabelSelector: params.Get("labelSelector"), Limit: maxExportItems + 1})
+		if err != nil {
+			utils.RespondError(response, err, utils.StatusCodeForError(err))
+			return
+		}
+		if len(list.Items) > maxExportItems {
+			utils.RespondError(response, fmt.Errorf("at most %d resources can be exported at once", maxExportItems), http.StatusBadRequest)
+			return
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	}
+	if len(names) > maxExportItems {
+		utils.RespondError(response, fmt.Errorf("at most %d resources can be exported at once", maxExportItems), http.StatusBadRequest)
+		return
+	}
+	for _, name := range names {
+		obj, err := client.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			utils.RespondError(response, err, utils.StatusCodeForError(err))
+			return
+		}
+		objs = append(objs, obj)
+	}
+
+	var exported []*unstructured.Unstructured
+	for _, obj := range objs {
+		if !asPipeline {
+			exported = append(exported, cleanForExport(obj))
+			continue
+		}
+		pipelineName := ""
+		if len(objs) == 1 {
+			pipelineName = params.Get("pipelineName")
+		}
+		split, err := exportPipeline(obj, pipelineName)
+		if err != nil {
+			utils.RespondError(response, err, http.StatusBadRequest)
+			return
+		}
+		// Runs of the same Pipeline share its exported spec. If the Pipeline
+		// changed between runs, the run's spec is exported as a Pipeline
+		// named after the run instead.
+		existing := findPipeline(exported, split[0].GetName())
+		if existing != nil && !reflect.DeepEqual(existing.Object["spec"], split[0].Object["spec"]) {
+			if split, err = exportPipeline(obj, obj.GetName()); err != nil {
+				utils.RespondError(response, err, http.StatusBadRequest)
+				return
+			}
+			existing = findPipeline(exported, split[0].GetName())
+			if existing != nil && !reflect.DeepEqual(existing.Object["spec"], split[0].Object["spec"]) {
+				utils.RespondError(response, fmt.Errorf("PipelineRun %s and another run use different specs for Pipeline %s", obj.GetName(), split[0].GetName()), http.StatusConflict)
+				return
+			}
+		}
+		if existing != nil {
+			split = split[1:]
+		}
+		exported = append(exported, split...)
+	}
+	writeExport(response, format, exported)
+}
@@ -1,5 +1,5 @@
 /*
-Copyright 2019-2022 The Tekton Authors
+Copyright 2019-2026 The Tekton Authors
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
@@ -14,10 +14,15 @@ limitations under the License.
 package endpoints
 
 import (
+	"errors"
+	"math"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
+	"github.com/tektoncd/dashboard/pkg/externallogs"
 	"github.com/tektoncd/dashboard/pkg/utils"
 )
 
@@ -31,7 +36,16 @@ func (r Resource) LogsProxy(response http.ResponseWriter, request *http.Request)
 
 	uri := strings.TrimPrefix(request.URL.Path, "/v1/logs-proxy") + "?" + parsedURL.RawQuery
 
-	if statusCode, err := utils.Proxy(request, response, r.Options.ExternalLogsURL+uri, http.DefaultClient); err != nil {
+	if statusCode, err := utils.Proxy(request, response, r.Options.ExternalLogsURL+uri, r.getExternalLogsClient()); err != nil {
+		var circuitOpenErr *externallogs.CircuitOpenError
+		var netErr net.Error
+		switch {
+		case errors.As(err, &circuitOpenErr):
+			response.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(circuitOpenErr.RetryAfter.Seconds()))))
+			statusCode = http.StatusServiceUnavailable
+		case errors.As(err, &netErr) && netErr.Timeout():
+			statusCode = http.StatusGatewayTimeout
+		}
 		utils.RespondError(response, err, statusCode)
 	}
 }
@@ -0,0 +1,341 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	"github.com/tektoncd/dashboard/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8sclientset "k8s.io/client-go/kubernetes"
+)
+
+const (
+	logHeartbeatInterval = 15 * time.Second
+	// maxLogLineSize is the longest log line we will relay, longer lines end the stream
+	maxLogLineSize = 1024 * 1024
+	// containerStartPollInterval is how often we check whether a container
+	// has started so its logs can be followed
+	containerStartPollInterval = time.Second
+
+	stepContainerPrefix = "step-"
+)
+
+// logLine is a single line of container log output along with the timestamp
+// added by the kubelet
+type logLine struct {
+	timestamp string
+	time      time.Time
+	text      string
+}
+
+// parseLogLine splits a line produced with PodLogOptions.Timestamps into its
+// timestamp and content
+func parseLogLine(raw string) logLine {
+	timestamp, text, found := strings.Cut(raw, " ")
+	if !found {
+		// Empty lines are sent with only the timestamp
+		text = ""
+	}
+	t, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return logLine{text: raw}
+	}
+	return logLine{timestamp: timestamp, time: t, text: text}
+}
+
+// streamContainerLogs follows the logs of a container and calls handle for
+// each line until the log ends, the context is done, or handle returns an error.
+// When since is non-zero only lines logged after that time are passed to handle.
+func streamContainerLogs(ctx context.Context, client k8sclientset.Interface, namespace, pod, container string, since time.Time, handle func(logLine) error) error {
+	options := &corev1.PodLogOptions{
+		Container:  container,
+		Follow:     true,
+		Timestamps: true,
+	}
+	if !since.IsZero() {
+		sinceTime := metav1.NewTime(since)
+		options.SinceTime = &sinceTime
+	}
+
+	stream, err := client.CoreV1().Pods(namespace).GetLogs(pod, options).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+	for scanner.Scan() {
+		line := parseLogLine(scanner.Text())
+		// sinceTime has a precision of seconds so we may receive lines the
+		// client has already seen
+		if !since.IsZero() && !line.time.IsZero() && !line.time.After(since) {
+			continue
+		}
+		if err := handle(line); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+// StreamStepLogs streams the logs of a step container as Server-Sent Events.
+// Each event carries the line's timestamp as its ID so clients can resume
+// from the last line received using the Last-Event-ID header.
+func (r Resource) StreamStepLogs(response http.ResponseWriter, request *http.Request) {
+	namespace := request.PathValue("namespace")
+	pod := request.PathValue("pod")
+	container := request.PathValue("container")
+	if !r.Options.IsNamespaceInScope(namespace) {
+		http.Error(response, "namespace not available", http.StatusForbidden)
+		return
+	}
+
+	var since time.Time
+	if lastEventID := request.Header.Get("Last-Event-Id"); lastEventID != "" {
+		t, err := time.Parse(time.RFC3339Nano, lastEventID)
+		if err != nil {
+			utils.RespondError(response, fmt.Errorf("invalid Last-Event-ID: %w", err), http.StatusBadRequest)
+			return
+		}
+		since = t
+	}
+
+	client, err := r.userK8sClient(request)
+	if err != nil {
+		utils.RespondError(response, err, http.StatusInternalServerError)
+		return
+	}
+
+	// Check the pod exists before committing to an event stream so we
+	// can still report errors with a meaningful status code
+	if _, err := client.CoreV1().Pods(namespace).Get(request.Context(), pod, metav1.GetOptions{}); err != nil {
+		utils.RespondError(response, err, utils.StatusCodeForError(err))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(request.Context())
+	var heartbeat sync.WaitGroup
+	// Stop the heartbeat before returning so it doesn't write to the
+	// response once the handler has finished
+	defer heartbeat.Wait()
+	defer cancel()
+
+	events := utils.NewEventWriter(response)
+	heartbeat.Go(func() { events.Heartbeat(ctx, logHeartbeatInterval) })
+
+	err = streamContainerLogs(ctx, client, namespace, pod, container, since, func(line logLine) error {
+		return events.WriteEvent(line.timestamp, "", line.text)
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		logging.Log.Errorf("Error streaming logs for %s/%s/%s: %s", namespace, pod, container, err.Error())
+		_ = events.WriteEvent("", "error", err.Error())
+		return
+	}
+
+	_ = events.WriteEvent("", "end", "")
+}
+
+// taskRunLogLine is the payload of the events sent by StreamTaskRunLogs
+type taskRunLogLine struct {
+	Step      string `json:"step"`
+	Timestamp string `json:"timestamp,omitempty"`
+	Line      string `json:"line"`
+}
+
+// getTaskRunPod returns the most recent pod created for a TaskRun
+func getTaskRunPod(ctx context.Context, client k8sclientset.Interface, namespace, taskRun string) (*corev1.Pod, error) {
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: taskRunLabel + "=" + taskRun,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pods.Items) == 0 {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, taskRun)
+	}
+
+	// Retries create a new pod for each attempt
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[j].CreationTimestamp.Before(&pods.Items[i].CreationTimestamp)
+	})
+	return &pods.Items[0], nil
+}
+
+// stepContainers returns the names of the step containers in a TaskRun pod, in order
+func stepContainers(pod *corev1.Pod) []string {
+	var containers []string
+	for _, container := range pod.Spec.Containers {
+		if strings.HasPrefix(container.Name, stepContainerPrefix) {
+			containers = append(containers, container.Name)
+		}
+	}
+	return containers
+}
+
+// containerStart tracks whether the logs of a container can be followed
+type containerStart struct {
+	// done is closed once the container has started or terminated, or the
+	// pod has completed without running it
+	done chan struct{}
+	// err is set before done is closed if the pod could not be read
+	err error
+}
+
+// wait blocks until the container can be followed or the context is done
+func (c *containerStart) wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.done:
+		return c.err
+	}
+}
+
+// watchContainerStarts returns a containerStart for each of the containers,
+// and a function polling the pod until all of them are done. A single poll is
+// shared by all containers so streaming a TaskRun with many steps doesn't
+// multiply the requests to the API server.
+func watchContainerStarts(ctx context.Context, client k8sclientset.Interface, namespace, pod string, containers []string) (map[string]*containerStart, func()) {
+	starts := make(map[string]*containerStart, len(containers))
+	for _, container := range containers {
+		starts[container] = &containerStart{done: make(chan struct{})}
+	}
+
+	pending := maps.Clone(starts)
+	poll := func() {
+		err := wait.PollUntilContextCancel(ctx, containerStartPollInterval, true, func(ctx context.Context) (bool, error) {
+			p, err := client.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+			completed := p.Status.Phase == corev1.PodSucceeded || p.Status.Phase == corev1.PodFailed
+			for _, status := range p.Status.ContainerStatuses {
+				if start, ok := pending[status.Name]; ok && (completed || status.State.Waiting == nil) {
+					close(start.done)
+					delete(pending, status.Name)
+				}
+			}
+			if completed {
+				// Containers missing from the statuses will never run
+				for name, start := range pending {
+					close(start.done)
+					delete(pending, name)
+				}
+			}
+			return len(pending) == 0, nil
+		})
+		for _, start := range pending {
+			if ctx.Err() == nil {
+				start.err = err
+			}
+			close(start.done)
+		}
+	}
+	return starts, poll
+}
+
+// StreamTaskRunLogs follows the logs of all steps of a TaskRun concurrently over
+// a single Server-Sent Events connection. Each line is tagged with the name of
+// its step and the stream ends once all steps have completed.
+func (r Resource) StreamTaskRunLogs(response http.ResponseWriter, request *http.Request) {
+	namespace := request.PathValue("namespace")
+	taskRun := request.PathValue("name")
+	if !r.Options.IsNamespaceInScope(namespace) {
+		http.Error(response, "namespace not available", http.StatusForbidden)
+		return
+	}
+
+	client, err := r.userK8sClient(request)
+	if err != nil {
+		utils.RespondError(response, err, http.StatusInternalServerError)
+		return
+	}
+
+	pod, err := getTaskRunPod(request.Context(), client, namespace, taskRun)
+	if err != nil {
+		utils.RespondError(response, err, utils.StatusCodeForError(err))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(request.Context())
+	var heartbeat sync.WaitGroup
+	defer heartbeat.Wait()
+	defer cancel()
+
+	events := utils.NewEventWriter(response)
+	heartbeat.Go(func() { events.Heartbeat(ctx, logHeartbeatInterval) })
+
+	writeEvent := func(event string, payload taskRunLogLine) error {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		return events.WriteEvent("", event, string(data))
+	}
+
+	containers := stepContainers(pod)
+	starts, pollContainerStarts := watchContainerStarts(ctx, client, namespace, pod.Name, containers)
+
+	var wg sync.WaitGroup
+	wg.Go(pollContainerStarts)
+	for _, container := range containers {
+		step := strings.TrimPrefix(container, stepContainerPrefix)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := starts[container].wait(ctx)
+			if err == nil {
+				err = streamContainerLogs(ctx, client, namespace, pod.Name, container, time.Time{}, func(line logLine) error {
+					return writeEvent("log", taskRunLogLine{Step: step, Timestamp: line.timestamp, Line: line.text})
+				})
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				logging.Log.Errorf("Error streaming logs for %s/%s/%s: %s", namespace, pod.Name, container, err.Error())
+				_ = writeEvent("error", taskRunLogLine{Step: step, Line: err.Error()})
+				return
+			}
+			_ = writeEvent("step-end", taskRunLogLine{Step: step})
+		}()
+	}
+	wg.Wait()
+
+	if ctx.Err() == nil {
+		_ = events.WriteEvent("", "end", "")
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	"github.com/tektoncd/dashboard/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// rerunOfLabel holds the name of the run a run is a rerun of, as set by
+	// the Dashboard UI
+	rerunOfLabel = "dashboard.tekton.dev/rerunOf"
+	// rerunIdentifier separates the name of the original run from the suffix
+	// generated for its reruns
+	rerunIdentifier = "-r-"
+	// maxRerunRequestSize limits the size of the overrides we accept
+	maxRerunRequestSize = 1024 * 1024
+)
+
+// RerunOverrides replaces the params and workspaces of a run when rerunning
+// it. Params and workspaces are matched by name, and added if the run does not
+// have them.
+type RerunOverrides struct {
+	Params []struct {
+		Name  string `json:"name"`
+		Value any    `json:"value"`
+	} `json:"params,omitempty"`
+	// Workspaces are workspace bindings, e.g. with a persistentVolumeClaim
+	Workspaces []map[string]any `json:"workspaces,omitempty"`
+}
+
+// validate returns an error if a param or workspace is missing its name or
+// a param is missing its value
+func (o RerunOverrides) validate() error {
+	for i, param := range o.Params {
+		if param.Name == "" {
+			return fmt.Errorf("params[%d].name is required", i)
+		}
+		if param.Value == nil {
+			return fmt.Errorf("params[%d].value is required", i)
+		}
+	}
+	for i, workspace := range o.Workspaces {
+		if name, _ := workspace["name"].(string); name == "" {
+			return fmt.Errorf("workspaces[%d].name is required", i)
+		}
+	}
+	return nil
+}
+
+// overrideByName replaces the entries of a list in the run's spec with the
+// overrides of the same name, adding the others
+func overrideByName(run *unstructured.Unstructured, field string, overrides []map[string]any) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+	entries, _, err := unstructured.NestedSlice(run.Object, "spec", field)
+	if err != nil {
+		return err
+	}
+	for _, override := range overrides {
+		replaced := false
+		for i, entry := range entries {
+			if existing, ok := entry.(map[string]any); ok && existing["name"] == override["name"] {
+				entries[i] = override
+				replaced = true
+			}
+		}
+		if !replaced {
+			entries = append(entries, override)
+		}
+	}
+	return unstructured.SetNestedSlice(run.Object, entries, "spec", field)
+}
+
+// apply applies the overrides to the run
+func (o RerunOverrides) apply(run *unstructured.Unstructured) error {
+	params := make([]map[string]any, 0, len(o.Params))
+	for _, param := range o.Params {
+		params = append(params, map[string]any{"name": param.Name, "value": param.Value})
+	}
+	if err := overrideByName(run, "params", params); err != nil {
+		return err
+	}
+	return overrideByName(run, "workspaces", o.Workspaces)
+}
+
+// rerunGenerateName returns the prefix of the name of a rerun, removing the
+// suffix of previous reruns so that reruns of reruns share the same prefix
+func rerunGenerateName(name string) string {
+	if i := strings.LastIndex(name, rerunIdentifier); i >= 0 {
+		name = name[:i]
+	}
+	return name + rerunIdentifier
+}
+
+// newRerun returns a copy of a run to create to rerun it, without its status
+// and the labels and annotations added by Tekton
+func newRerun(original *unstructured.Unstructured) *unstructured.Unstructured {
+	run := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": original.GetAPIVersion(),
+		"kind":       original.GetKind(),
+		"spec":       original.Object["spec"],
+	}}
+	run = run.DeepCopy()
+	run.SetNamespace(original.GetNamespace())
+	run.SetGenerateName(rerunGenerateName(original.GetName()))
+
+	labels := map[string]string{}
+	for key, value := range original.GetLabels() {
+		if !strings.HasPrefix(key, "tekton.dev/") {
+			labels[key] = value
+		}
+	}
+	labels[rerunOfLabel] = original.GetName()
+	run.SetLabels(labels)
+
+	annotations := map[string]string{}
+	for key, value := range original.GetAnnotations() {
+		// Some tekton.dev annotations may still be required on PipelineRuns,
+		// but a run with tekton.dev/v1beta1TaskRuns would adopt the status of
+		// the original TaskRuns instead of executing
+		if key == "kubectl.kubernetes.io/last-applied-configuration" || key == "tekton.dev/v1beta1TaskRuns" ||
+			(original.GetKind() == "TaskRun" && strings.HasPrefix(key, "tekton.dev/")) {
+			continue
+		}
+		annotations[key] = value
+	}
+	if len(annotations) > 0 {
+		run.SetAnnotations(annotations)
+	}
+
+	// Do not create the rerun cancelled or pending like the original
+	unstructured.RemoveNestedField(run.Object, "spec", "status")
+	return run
+}
+
+// rerun creates a new run from the spec of an existing PipelineRun or
+// TaskRun, with the params and workspaces overridden by the request body,
+// as the user sending the request
+func (r Resource) rerun(response http.ResponseWriter, request *http.Request, gvr schema.GroupVersionResource) {
+	ctx := request.Context()
+	namespace := request.PathValue("namespace")
+	name := request.PathValue("name")
+	if !r.Options.IsNamespaceInScope(namespace) {
+		http.Error(response, "namespace not available", http.StatusForbidden)
+		return
+	}
+
+	request.Body = http.MaxBytesReader(response, request.Body, maxRerunRequestSize)
+	var overrides RerunOverrides
+	if err := json.NewDecoder(request.Body).Decode(&overrides); err != nil && !errors.Is(err, io.EOF) {
+		statusCode := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			statusCode = http.StatusRequestEntityTooLarge
+		}
+		utils.RespondError(response, err, statusCode)
+		return
+	}
+	if err := overrides.validate(); err != nil {
+		utils.RespondError(response, err, http.StatusBadRequest)
+		return
+	}
+
+	client, err := r.userDynamicClient(request)
+	if err != nil {
+		utils.RespondError(response, err, http.StatusInternalServerError)
+		return
+	}
+	original, err := client.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		utils.RespondError(response, err, utils.StatusCodeForError(err))
+		return
+	}
+	run := newRerun(original)
+	if err := overrides.apply(run); err != nil {
+		utils.RespondError(response, err, http.StatusBadRequest)
+		return
+	}
+	created, err := client.Resource(gvr).Namespace(namespace).Create(ctx, run, metav1.CreateOptions{})
+	if err != nil {
+		utils.RespondError(response, err, utils.StatusCodeForError(err))
+		return
+	}
+	logging.Log.Infof("Created %s %s/%s to rerun %s", created.GetKind(), namespace, created.GetName(), name)
+
+	response.Header().Set("Content-Type", "application/json")
+	response.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(response).Encode(created.Object); err != nil {
+		logging.Log.Error("Failed encoding rerun")
+	}
+}
+
+// RerunPipelineRun creates a new PipelineRun from the spec of an existing one
+func (r Resource) RerunPipelineRun(response http.ResponseWriter, request *http.Request) {
+	r.rerun(response, request, pipelineRunsGVR)
+}
+
+// RerunTaskRun creates a new TaskRun from the spec of an existing one
+func (r Resource) RerunTaskRun(response http.ResponseWriter, request *http.Request) {
+	r.rerun(response, request, taskRunsGVR)
+}
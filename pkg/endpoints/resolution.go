@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+var resolutionRequestsGVR = schema.GroupVersionResource{Group: "resolution.tekton.dev", Version: "v1beta1", Resource: "resolutionrequests"}
+
+const (
+	// resolverTypeLabel selects the resolver handling a ResolutionRequest
+	resolverTypeLabel = "resolution.tekton.dev/type"
+	// resolutionTimeout limits the time waiting for a resolver
+	resolutionTimeout = 30 * time.Second
+	// resolutionPollInterval is the interval between checks of the status of
+	// a ResolutionRequest
+	resolutionPollInterval = 500 * time.Millisecond
+	// resolutionCleanupTimeout limits the time deleting a ResolutionRequest
+	resolutionCleanupTimeout = 10 * time.Second
+)
+
+// RemoteRef references a resource fetched by a Tekton resolver, e.g. from a
+// git repository or a bundle
+type RemoteRef struct {
+	Resolver string `json:"resolver"`
+	Params   []any  `json:"params,omitempty"`
+}
+
+// resolve fetches a resource with a Tekton resolver, by creating a
+// ResolutionRequest and waiting for its data. The request is deleted once
+// resolved, so that no run is created to resolve the resource. A dry-run of
+// the PipelineRun would not do, as Tekton resolves remote Pipelines in its
+// controller rather than on admission.
+func resolve(ctx context.Context, client dynamic.Interface, namespace string, ref RemoteRef) (*unstructured.Unstructured, error) {
+	request := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": resolutionRequestsGVR.GroupVersion().String(),
+		"kind":       "ResolutionRequest",
+		"spec":       map[string]any{"params": ref.Params},
+	}}
+	request.SetGenerateName("dashboard-")
+	request.SetNamespace(namespace)
+	request.SetLabels(map[string]string{resolverTypeLabel: ref.Resolver})
+
+	requests := client.Resource(resolutionRequestsGVR).Namespace(namespace)
+	created, err := requests.Create(ctx, request, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		// The request context may be done, e.g. on timeout
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), resolutionCleanupTimeout)
+		defer cancel()
+		if err := requests.Delete(ctx, created.GetName(), metav1.DeleteOptions{}); err != nil {
+			logging.Log.Warnf("Error deleting ResolutionRequest %s/%s: %s", namespace, created.GetName(), err.Error())
+		}
+	}()
+
+	var data string
+	err = wait.PollUntilContextTimeout(ctx, resolutionPollInterval, resolutionTimeout, true, func(ctx context.Context) (bool, error) {
+		resolved, err := requests.Get(ctx, created.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		conditions, _, _ := unstructured.NestedSlice(resolved.Object, "status", "conditions")
+		for _, c := range conditions {
+			condition, ok := c.(map[string]any)
+			if !ok || condition["type"] != "Succeeded" {
+				continue
+			}
+			switch condition["status"] {
+			case "True":
+				data, _, _ = unstructured.NestedString(resolved.Object, "status", "data")
+				return true, nil
+			case "False":
+				return false, fmt.Errorf("resolution failed: %v", condition["message"])
+			}
+		}
+		return false, nil
+	})
+	if wait.Interrupted(err) {
+		return nil, errors.New("timed out waiting for the resolver")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resolved data: %w", err)
+	}
+	resolved := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(decoded, &resolved.Object); err != nil {
+		return nil, fmt.Errorf("invalid resolved data: %w", err)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	"github.com/tektoncd/dashboard/pkg/runs"
+	"github.com/tektoncd/dashboard/pkg/utils"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// parseRunsQuery returns the runs query from the request parameters
+func parseRunsQuery(request *http.Request) (runs.Query, error) {
+	params := request.URL.Query()
+	now := time.Now()
+
+	q := runs.Query{
+		Namespace: params.Get("namespace"),
+		Pipeline:  params.Get("pipeline"),
+		Continue:  params.Get("continue"),
+	}
+	var err error
+	if q.Kind, err = runs.ParseKind(params.Get("kind")); err != nil {
+		return q, err
+	}
+	if q.Labels, err = labels.Parse(params.Get("labelSelector")); err != nil {
+		return q, fmt.Errorf("invalid labelSelector: %w", err)
+	}
+	for _, value := range strings.FieldsFunc(params.Get("status"), func(c rune) bool { return c == ',' }) {
+		status, err := runs.ParseStatus(value)
+		if err != nil {
+			return q, err
+		}
+		q.Statuses = append(q.Statuses, status)
+	}
+	if q.Since, err = parseTimeParam(params.Get("since"), now); err != nil {
+		return q, err
+	}
+	if q.Until, err = parseTimeParam(params.Get("until"), now); err != nil {
+		return q, err
+	}
+	if q.Sort, err = runs.ParseSort(params.Get("sort")); err != nil {
+		return q, err
+	}
+	switch order := params.Get("order"); order {
+	case "", "desc":
+	case "asc":
+		q.Ascending = true
+	default:
+		return q, fmt.Errorf("invalid order %q, expected asc or desc", order)
+	}
+	if limit := params.Get("limit"); limit != "" {
+		if q.Limit, err = strconv.Atoi(limit); err != nil || q.Limit <= 0 {
+			return q, fmt.Errorf("invalid limit %q, expected a positive number", limit)
+		}
+	}
+	return q, nil
+}
+
+// GetRuns responds with a page of PipelineRuns or TaskRuns from the run
+// index, filtered and sorted according to the request parameters, in the
+// form of a Kubernetes list
+func (r Resource) GetRuns(response http.ResponseWriter, request *http.Request) {
+	q, err := parseRunsQuery(request)
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !r.Options.IsNamespaceInScope(q.Namespace) {
+		http.Error(response, "namespace not available", http.StatusForbidden)
+		return
+	}
+	allowed, err := r.namespaceFilter(request)
+	if err != nil {
+		utils.RespondError(response, err, http.StatusInternalServerError)
+		return
+	}
+	gvr := q.Kind.GroupVersionResource()
+	q.Allowed = func(namespace string) bool { return allowed(gvr, namespace) }
+
+	page, err := r.Runs.Query(q)
+	switch {
+	case errors.Is(err, runs.ErrNotSynced):
+		response.Header().Set("Retry-After", "5")
+		http.Error(response, err.Error(), http.StatusServiceUnavailable)
+		return
+	case err != nil:
+		http.Error(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	items := make([]map[string]any, 0, len(page.Items))
+	for _, item := range page.Items {
+		items = append(items, item.Object)
+	}
+	metadata := map[string]any{}
+	if page.Continue != "" {
+		metadata["continue"] = page.Continue
+		metadata["remainingItemCount"] = page.Remaining
+	}
+	list := map[string]any{
+		"apiVersion": q.Kind.GroupVersion().String(),
+		"kind":       string(q.Kind) + "List",
+		"metadata":   metadata,
+		"items":      items,
+	}
+
+	response.Header().Set("Content-Type", "application/json")
+	response.Header().Set("Cache-Control", "no-cache")
+	if err := json.NewEncoder(response).Encode(list); err != nil {
+		logging.Log.Error("Failed encoding runs")
+	}
+}
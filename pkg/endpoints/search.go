@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	"github.com/tektoncd/dashboard/pkg/search"
+	"github.com/tektoncd/dashboard/pkg/utils"
+)
+
+// parseSearchQuery returns the search query from the request parameters
+func parseSearchQuery(request *http.Request) (search.Query, error) {
+	params := request.URL.Query()
+
+	q := search.Query{
+		Text:      params.Get("q"),
+		Namespace: params.Get("namespace"),
+	}
+	var err error
+	if q.Kinds, err = search.ParseKinds(strings.FieldsFunc(params.Get("kind"), func(c rune) bool { return c == ',' })); err != nil {
+		return q, err
+	}
+	if limit := params.Get("limit"); limit != "" {
+		if q.Limit, err = strconv.Atoi(limit); err != nil || q.Limit <= 0 {
+			return q, fmt.Errorf("invalid limit %q, expected a positive number", limit)
+		}
+	}
+	return q, nil
+}
+
+// GetSearch responds with the Tekton resources matching the search text in
+// their name, labels, annotations, params or results, the best matches first
+func (r Resource) GetSearch(response http.ResponseWriter, request *http.Request) {
+	q, err := parseSearchQuery(request)
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !r.Options.IsNamespaceInScope(q.Namespace) {
+		http.Error(response, "namespace not available", http.StatusForbidden)
+		return
+	}
+	if q.Allowed, err = r.namespaceFilter(request); err != nil {
+		utils.RespondError(response, err, http.StatusInternalServerError)
+		return
+	}
+
+	results, err := r.Search.Search(q)
+	switch {
+	case errors.Is(err, search.ErrNotSynced):
+		response.Header().Set("Retry-After", "5")
+		http.Error(response, err.Error(), http.StatusServiceUnavailable)
+		return
+	case err != nil:
+		http.Error(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response.Header().Set("Content-Type", "application/json")
+	response.Header().Set("Cache-Control", "no-cache")
+	if err := json.NewEncoder(response).Encode(results); err != nil {
+		logging.Log.Error("Failed encoding search results")
+	}
+}
@@ -0,0 +1,473 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	"github.com/tektoncd/dashboard/pkg/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/dynamic"
+)
+
+// maxStartRunRequestSize limits the size of the requests we accept
+const maxStartRunRequestSize = 1024 * 1024
+
+// Types of Pipeline params
+const (
+	paramTypeString = "string"
+	paramTypeArray  = "array"
+	paramTypeObject = "object"
+)
+
+// workspaceSources are the volume sources a workspace may be bound to
+var workspaceSources = []string{"persistentVolumeClaim", "volumeClaimTemplate", "emptyDir", "configMap", "secret", "projected", "csi"}
+
+// RunParam is the value of a param, a string, an array of strings, or an
+// object with string values
+type RunParam struct {
+	Name  string `json:"name"`
+	Value any    `json:"value"`
+}
+
+// StartRunRequest configures the PipelineRun created for a Pipeline
+type StartRunRequest struct {
+	// Name of the PipelineRun, generated from the name of the Pipeline if empty
+	Name   string            `json:"name,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Params []RunParam        `json:"params,omitempty"`
+	// Workspaces are workspace bindings, e.g. with a persistentVolumeClaim
+	Workspaces         []map[string]any  `json:"workspaces,omitempty"`
+	ServiceAccountName string            `json:"serviceAccountName,omitempty"`
+	NodeSelector       map[string]string `json:"nodeSelector,omitempty"`
+	Timeouts           map[string]string `json:"timeouts,omitempty"`
+	// Pending creates the PipelineRun without starting it
+	Pending bool `json:"pending,omitempty"`
+	// PipelineRef resolves the Pipeline with a Tekton resolver instead of
+	// from the cluster
+	PipelineRef *RemoteRef `json:"pipelineRef,omitempty"`
+}
+
+// paramSpec is a param declared by a Pipeline
+type paramSpec struct {
+	name       string
+	paramType  string
+	hasDefault bool
+	defaults   map[string]any
+	enum       []string
+	properties []string
+}
+
+// declaredParams returns the params declared by a Pipeline. The type of a param
+// defaults to the type of its default value, as in Tekton.
+func declaredParams(pipeline *unstructured.Unstructured) []paramSpec {
+	values, _, _ := unstructured.NestedSlice(pipeline.Object, "spec", "params")
+	var specs []paramSpec
+	for _, value := range values {
+		param, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		spec := paramSpec{paramType: paramTypeString}
+		spec.name, _ = param["name"].(string)
+		defaultValue, hasDefault := param["default"]
+		spec.hasDefault = hasDefault && defaultValue != nil
+		switch v := defaultValue.(type) {
+		case []any:
+			spec.paramType = paramTypeArray
+		case map[string]any:
+			spec.paramType = paramTypeObject
+			spec.defaults = v
+		}
+		if paramType, _ := param["type"].(string); paramType != "" {
+			spec.paramType = paramType
+		}
+		enum, _ := param["enum"].([]any)
+		for _, e := range enum {
+			if s, ok := e.(string); ok {
+				spec.enum = append(spec.enum, s)
+			}
+		}
+		properties, _ := param["properties"].(map[string]any)
+		for key := range properties {
+			spec.properties = append(spec.properties, key)
+		}
+		slices.Sort(spec.properties)
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// isStringArray returns true if the value is an array of strings
+func isStringArray(value any) bool {
+	items, ok := value.([]any)
+	if !ok {
+		return false
+	}
+	for _, item := range items {
+		if _, ok := item.(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// validateParamValue validates a param value against its declared type, enum
+// and properties
+func validateParamValue(spec paramSpec, value any, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	switch spec.paramType {
+	case paramTypeArray:
+		if !isStringArray(value) {
+			errs = append(errs, field.TypeInvalid(path, value, "must be an array of strings"))
+		}
+	case paramTypeObject:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return append(errs, field.TypeInvalid(path, value, "must be an object with string values"))
+		}
+		for key, v := range object {
+			if _, ok := v.(string); !ok {
+				errs = append(errs, field.TypeInvalid(path.Key(key), v, "must be a string"))
+			}
+		}
+		for _, key := range spec.properties {
+			_, provided := object[key]
+			_, defaulted := spec.defaults[key]
+			if !provided && !defaulted {
+				errs = append(errs, field.Required(path.Key(key), "declared by the Pipeline without a default"))
+			}
+		}
+	default:
+		s, ok := value.(string)
+		if !ok {
+			return append(errs, field.TypeInvalid(path, value, "must be a string"))
+		}
+		if len(spec.enum) > 0 && !slices.Contains(spec.enum, s) {
+			errs = append(errs, field.NotSupported(path, s, spec.enum))
+		}
+	}
+	return errs
+}
+
+// validateParams validates the params of a run against the params declared by
+// the Pipeline
+func validateParams(pipeline *unstructured.Unstructured, params []RunParam, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	specs := declaredParams(pipeline)
+	provided := map[string]bool{}
+	for i, param := range params {
+		paramPath := path.Index(i)
+		switch {
+		case param.Name == "":
+			errs = append(errs, field.Required(paramPath.Child("name"), ""))
+			continue
+		case provided[param.Name]:
+			errs = append(errs, field.Duplicate(paramPath.Child("name"), param.Name))
+			continue
+		}
+		provided[param.Name] = true
+		declared := slices.IndexFunc(specs, func(s paramSpec) bool { return s.name == param.Name })
+		if declared < 0 {
+			errs = append(errs, field.NotFound(paramPath.Child("name"), param.Name))
+			continue
+		}
+		if param.Value == nil {
+			errs = append(errs, field.Required(paramPath.Child("value"), ""))
+			continue
+		}
+		errs = append(errs, validateParamValue(specs[declared], param.Value, paramPath.Child("value"))...)
+	}
+	for _, spec := range specs {
+		if !provided[spec.name] && !spec.hasDefault {
+			errs = append(errs, field.Required(path.Key(spec.name), "declared by the Pipeline without a default"))
+		}
+	}
+	return errs
+}
+
+// workspaceReferences locates the resources referenced by workspace bindings,
+// which must exist when the run starts
+var workspaceReferences = []struct {
+	source string
+	name   string
+	gvr    schema.GroupVersionResource
+}{
+	{"persistentVolumeClaim", "claimName", schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumeclaims"}},
+	{"configMap", "name", schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}},
+	{"secret", "secretName", schema.GroupVersionResource{Version: "v1", Resource: "secrets"}},
+}
+
+// validateWorkspaces validates the workspace bindings of a run against the
+// workspaces declared by the Pipeline, and checks the volumes they reference
+// exist. Volumes the user is not allowed to get are not checked.
+func validateWorkspaces(ctx context.Context, client dynamic.Interface, namespace string, pipeline *unstructured.Unstructured, bindings []map[string]any, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	declared, _, _ := unstructured.NestedSlice(pipeline.Object, "spec", "workspaces")
+	var names []string
+	optional := map[string]bool{}
+	for _, value := range declared {
+		if workspace, ok := value.(map[string]any); ok {
+			name, _ := workspace["name"].(string)
+			names = append(names, name)
+			optional[name], _ = workspace["optional"].(bool)
+		}
+	}
+
+	bound := map[string]bool{}
+	for i, binding := range bindings {
+		bindingPath := path.Index(i)
+		name, _ := binding["name"].(string)
+		switch _, isDeclared := optional[name]; {
+		case name == "":
+			errs = append(errs, field.Required(bindingPath.Child("name"), ""))
+			continue
+		case bound[name]:
+			errs = append(errs, field.Duplicate(bindingPath.Child("name"), name))
+			continue
+		case !isDeclared:
+			errs = append(errs, field.NotFound(bindingPath.Child("name"), name))
+			continue
+		}
+		bound[name] = true
+
+		var sources []string
+		for _, source := range workspaceSources {
+			if _, ok := binding[source]; ok {
+				sources = append(sources, source)
+			}
+		}
+		if len(sources) != 1 {
+			errs = append(errs, field.Invalid(bindingPath, sources, fmt.Sprintf("exactly one of %v is required", workspaceSources)))
+			continue
+		}
+
+		for _, ref := range workspaceReferences {
+			source, ok := binding[ref.source].(map[string]any)
+			if !ok {
+				continue
+			}
+			if isOptional, _ := source["optional"].(bool); isOptional {
+				continue
+			}
+			refName, _ := source[ref.name].(string)
+			refPath := bindingPath.Child(ref.source, ref.name)
+			if refName == "" {
+				errs = append(errs, field.Required(refPath, ""))
+				continue
+			}
+			_, err := client.Resource(ref.gvr).Namespace(namespace).Get(ctx, refName, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				errs = append(errs, field.NotFound(refPath, refName))
+			}
+		}
+	}
+	for _, name := range names {
+		if !optional[name] && !bound[name] {
+			errs = append(errs, field.Required(path.Key(name), "declared by the Pipeline and not optional"))
+		}
+	}
+	return errs
+}
+
+// validateTimeouts validates the pipeline, tasks and finally timeouts
+func validateTimeouts(timeouts map[string]string, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for key, value := range timeouts {
+		if !slices.Contains([]string{"pipeline", "tasks", "finally"}, key) {
+			errs = append(errs, field.NotSupported(path.Key(key), key, []string{"pipeline", "tasks", "finally"}))
+			continue
+		}
+		if d, err := time.ParseDuration(value); err != nil || d < 0 {
+			errs = append(errs, field.Invalid(path.Key(key), value, "must be a duration such as 1h30m"))
+		}
+	}
+	return errs
+}
+
+// newPipelineRun returns the PipelineRun to create for a Pipeline
+func (s StartRunRequest) newPipelineRun(namespace, pipeline string) *unstructured.Unstructured {
+	run := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": pipelineRunsGVR.GroupVersion().String(),
+		"kind":       "PipelineRun",
+		"spec":       map[string]any{},
+	}}
+	run.SetNamespace(namespace)
+	if s.Name != "" {
+		run.SetName(s.Name)
+	} else {
+		run.SetGenerateName(pipeline + "-run-")
+	}
+	if len(s.Labels) > 0 {
+		run.SetLabels(s.Labels)
+	}
+
+	spec := run.Object["spec"].(map[string]any)
+	if s.PipelineRef != nil {
+		pipelineRef := map[string]any{"resolver": s.PipelineRef.Resolver}
+		if len(s.PipelineRef.Params) > 0 {
+			pipelineRef["params"] = s.PipelineRef.Params
+		}
+		spec["pipelineRef"] = pipelineRef
+	} else {
+		spec["pipelineRef"] = map[string]any{"name": pipeline}
+	}
+	if len(s.Params) > 0 {
+		params := make([]any, 0, len(s.Params))
+		for _, param := range s.Params {
+			params = append(params, map[string]any{"name": param.Name, "value": param.Value})
+		}
+		spec["params"] = params
+	}
+	if len(s.Workspaces) > 0 {
+		workspaces := make([]any, 0, len(s.Workspaces))
+		for _, workspace := range s.Workspaces {
+			workspaces = append(workspaces, workspace)
+		}
+		spec["workspaces"] = workspaces
+	}
+	if s.ServiceAccountName != "" {
+		spec["taskRunTemplate"] = map[string]any{"serviceAccountName": s.ServiceAccountName}
+	}
+	if len(s.NodeSelector) > 0 {
+		nodeSelector := map[string]any{}
+		for key, value := range s.NodeSelector {
+			nodeSelector[key] = value
+		}
+		spec["podTemplate"] = map[string]any{"nodeSelector": nodeSelector}
+	}
+	if len(s.Timeouts) > 0 {
+		timeouts := map[string]any{}
+		for key, value := range s.Timeouts {
+			timeouts[key] = value
+		}
+		spec["timeouts"] = timeouts
+	}
+	if s.Pending {
+		spec["status"] = pipelineRunPending
+	}
+	return run
+}
+
+// respondStatus writes a Kubernetes API error as a Status, so that clients can
+// show the causes of validation errors next to the fields they relate to
+func respondStatus(response http.ResponseWriter, err error) {
+	var apiStatus apierrors.APIStatus
+	if !errors.As(err, &apiStatus) || apiStatus.Status().Code == 0 {
+		utils.RespondError(response, err, http.StatusInternalServerError)
+		return
+	}
+	status := apiStatus.Status()
+	status.APIVersion, status.Kind = "v1", "Status"
+	response.Header().Set("Content-Type", "application/json")
+	response.WriteHeader(int(status.Code))
+	if err := json.NewEncoder(response).Encode(status); err != nil {
+		logging.Log.Error("Failed encoding status")
+	}
+}
+
+// StartPipelineRun validates the params and workspaces of a new PipelineRun
+// against its Pipeline, and creates it as the user sending the request. All
+// invalid fields are reported in the causes of an Invalid Status, without
+// creating the run.
+func (r Resource) StartPipelineRun(response http.ResponseWriter, request *http.Request) {
+	ctx := request.Context()
+	namespace := request.PathValue("namespace")
+	name := request.PathValue("name")
+	if !r.Options.IsNamespaceInScope(namespace) {
+		http.Error(response, "namespace not available", http.StatusForbidden)
+		return
+	}
+
+	request.Body = http.MaxBytesReader(response, request.Body, maxStartRunRequestSize)
+	var start StartRunRequest
+	if err := json.NewDecoder(request.Body).Decode(&start); err != nil && !errors.Is(err, io.EOF) {
+		statusCode := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			statusCode = http.StatusRequestEntityTooLarge
+		}
+		utils.RespondError(response, err, statusCode)
+		return
+	}
+
+	client, err := r.userDynamicClient(request)
+	if err != nil {
+		utils.RespondError(response, err, http.StatusInternalServerError)
+		return
+	}
+
+	var pipeline *unstructured.Unstructured
+	if start.PipelineRef != nil {
+		if start.PipelineRef.Resolver == "" {
+			respondStatus(response, apierrors.NewInvalid(schema.GroupKind{Group: "tekton.dev", Kind: "PipelineRun"}, start.Name,
+				field.ErrorList{field.Required(field.NewPath("pipelineRef", "resolver"), "")}))
+			return
+		}
+		pipeline, err = resolve(ctx, client, namespace, *start.PipelineRef)
+		if err == nil && pipeline.GetKind() != "Pipeline" {
+			err = fmt.Errorf("the resolver returned a %s instead of a Pipeline", pipeline.GetKind())
+		}
+		var apiStatus apierrors.APIStatus
+		if errors.As(err, &apiStatus) {
+			// Creating or reading the ResolutionRequest failed, e.g. as the
+			// user is not allowed to
+			respondStatus(response, err)
+			return
+		}
+		if err != nil {
+			utils.RespondError(response, fmt.Errorf("error resolving Pipeline: %w", err), http.StatusBadGateway)
+			return
+		}
+	} else {
+		if pipeline, err = client.Resource(pipelinesGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{}); err != nil {
+			respondStatus(response, err)
+			return
+		}
+	}
+
+	var errs field.ErrorList
+	errs = append(errs, validateParams(pipeline, start.Params, field.NewPath("params"))...)
+	errs = append(errs, validateWorkspaces(ctx, client, namespace, pipeline, start.Workspaces, field.NewPath("workspaces"))...)
+	errs = append(errs, validateTimeouts(start.Timeouts, field.NewPath("timeouts"))...)
+	if len(errs) > 0 {
+		respondStatus(response, apierrors.NewInvalid(schema.GroupKind{Group: "tekton.dev", Kind: "PipelineRun"}, start.Name, errs))
+		return
+	}
+
+	created, err := client.Resource(pipelineRunsGVR).Namespace(namespace).Create(ctx, start.newPipelineRun(namespace, name), metav1.CreateOptions{})
+	if err != nil {
+		respondStatus(response, err)
+		return
+	}
+	logging.Log.Infof("Created PipelineRun %s/%s for Pipeline %s", namespace, created.GetName(), name)
+
+	response.Header().Set("Content-Type", "application/json")
+	response.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(response).Encode(created.Object); err != nil {
+		logging.Log.Error("Failed encoding PipelineRun")
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	"github.com/tektoncd/dashboard/pkg/runs"
+	"github.com/tektoncd/dashboard/pkg/stats"
+	"github.com/tektoncd/dashboard/pkg/utils"
+)
+
+// parseTimeParam parses an absolute RFC 3339 time, or a duration before now
+// such as 168h
+func parseTimeParam(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected an RFC 3339 time or a duration such as 168h", value)
+	}
+	return now.Add(-d), nil
+}
+
+// parseStatsQuery returns the statistics query from the request parameters
+func (r Resource) parseStatsQuery(request *http.Request) (stats.Query, error) {
+	params := request.URL.Query()
+	now := time.Now()
+
+	var q stats.Query
+	var err error
+	if q.Kind, err = runs.ParseKind(params.Get("kind")); err != nil {
+		return q, err
+	}
+	if q.Since, err = parseTimeParam(params.Get("since"), now); err != nil {
+		return q, err
+	}
+	if q.Until, err = parseTimeParam(params.Get("until"), now); err != nil {
+		return q, err
+	}
+	groupBy := params.Get("groupBy")
+	if groupBy == "" {
+		groupBy = stats.GroupByPipeline
+	}
+	if q.GroupBy, err = stats.ParseGroupBy(strings.FieldsFunc(groupBy, func(c rune) bool { return c == ',' })); err != nil {
+		return q, err
+	}
+	if q.Bucket, err = stats.ParseBucket(params.Get("bucket")); err != nil {
+		return q, err
+	}
+	q.Pipeline = params.Get("pipeline")
+	q.Namespace = params.Get("namespace")
+	return q, nil
+}
+
+// GetStats responds with the success, failure and cancellation counts and
+// the duration and queue time percentiles of PipelineRuns or TaskRuns,
+// grouped by pipeline, namespace or time bucket
+func (r Resource) GetStats(response http.ResponseWriter, request *http.Request) {
+	q, err := r.parseStatsQuery(request)
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !r.Options.IsNamespaceInScope(q.Namespace) {
+		http.Error(response, "namespace not available", http.StatusForbidden)
+		return
+	}
+	allowed, err := r.namespaceFilter(request)
+	if err != nil {
+		utils.RespondError(response, err, http.StatusInternalServerError)
+		return
+	}
+	gvr := q.Kind.GroupVersionResource()
+	q.Allowed = func(namespace string) bool { return allowed(gvr, namespace) }
+
+	result, err := r.Stats.Compute(q)
+	if errors.Is(err, stats.ErrNotSynced) {
+		response.Header().Set("Retry-After", "5")
+		http.Error(response, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	response.Header().Set("Content-Type", "application/json")
+	response.Header().Set("Cache-Control", "no-cache")
+	if err := json.NewEncoder(response).Encode(result); err != nil {
+		logging.Log.Error("Failed encoding run statistics")
+	}
+}
@@ -1,5 +1,5 @@
 /*
-Copyright 2019-2024 The Tekton Authors
+Copyright 2019-2026 The Tekton Authors
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
@@ -14,6 +14,16 @@ limitations under the License.
 package endpoints
 
 import (
+	"io/fs"
+	"net/http"
+	"slices"
+
+	"github.com/tektoncd/dashboard/pkg/clusters"
+	"github.com/tektoncd/dashboard/pkg/runs"
+	"github.com/tektoncd/dashboard/pkg/search"
+	"github.com/tektoncd/dashboard/pkg/stats"
+	"github.com/tektoncd/dashboard/pkg/watchcache"
+	"k8s.io/client-go/dynamic"
 	k8sclientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
@@ -29,7 +39,95 @@ type Options struct {
 	LogoutURL          string
 	StreamLogs         bool
 	ExternalLogsURL    string
-	XFrameOptions      string
+	BasePath           string
+	// ExternalURL is the URL users access the Dashboard at, if known
+	ExternalURL string
+	// AllowedOrigins are additional origins allowed to send requests, the
+	// host may start with a wildcard label, e.g. https://*.example.com
+	AllowedOrigins []string
+	// TrustedProxies are the CIDRs of proxies whose X-Forwarded-Host header is trusted
+	TrustedProxies []string
+	// BrandingDir contains the branding configuration and assets, usually
+	// mounted from a ConfigMap
+	BrandingDir string
+	Branding    Branding
+
+	ContentSecurityPolicy ContentSecurityPolicyOptions
+	SecurityHeaders       SecurityHeadersOptions
+	CSRF                  CSRFOptions
+	RateLimit             RateLimitOptions
+}
+
+// RateLimitOptions configures the limits applied to API requests from each client
+type RateLimitOptions struct {
+	Enabled bool
+	// UserHeader identifies the user sending a request, e.g. X-Forwarded-User
+	// set by an authenticating proxy. It is only used for requests sent by
+	// one of the trusted proxies, clients are identified by their IP address
+	// otherwise.
+	UserHeader string
+	// Limits for each class of request, in the form qps=20,burst=40,concurrent=10
+	List   string
+	Watch  string
+	Log    string
+	Mutate string
+}
+
+// CSRFOptions configures the protection against cross-site request forgery
+type CSRFOptions struct {
+	// Mode is either header or token
+	Mode string
+	// SecretFile contains the key used to sign tokens in token mode. If not
+	// set a random key is generated on startup.
+	SecretFile string
+}
+
+// SecurityHeadersOptions configures the security headers added to every
+// response, headers with an empty value are omitted
+type SecurityHeadersOptions struct {
+	// StrictTransportSecurity is only sent on requests received over TLS
+	StrictTransportSecurity   string
+	ReferrerPolicy            string
+	PermissionsPolicy         string
+	ContentTypeOptions        string
+	CrossOriginOpenerPolicy   string
+	CrossOriginResourcePolicy string
+	XFrameOptions             string
+	// FrameAncestors lists the sources allowed to embed the Dashboard. If empty
+	// it is derived from XFrameOptions.
+	FrameAncestors []string
+}
+
+// GetFrameAncestors returns the sources allowed by the frame-ancestors directive,
+// or nil if framing is not restricted
+func (o SecurityHeadersOptions) GetFrameAncestors() []string {
+	if len(o.FrameAncestors) > 0 {
+		return o.FrameAncestors
+	}
+	switch o.XFrameOptions {
+	case "":
+		return nil
+	case "SAMEORIGIN":
+		return []string{"'self'"}
+	default:
+		return []string{"'none'"}
+	}
+}
+
+// ContentSecurityPolicyOptions configures the Content-Security-Policy of the web UI
+type ContentSecurityPolicyOptions struct {
+	// Additional sources allowed for each directive
+	ScriptSrc  []string
+	StyleSrc   []string
+	ConnectSrc []string
+	ImgSrc     []string
+	FontSrc    []string
+	// StyleNonce requires inline styles to carry the request's nonce instead of
+	// allowing all inline styles
+	StyleNonce bool
+	// ReportURI and ReportTo configure where violations are reported
+	ReportURI string
+	ReportTo  string
 }
 
 // GetPipelinesNamespace returns the PipelinesNamespace property if set
@@ -52,9 +150,35 @@ func (o Options) GetTriggersNamespace() string {
 	return o.InstallNamespace
 }
 
+// IsNamespaceInScope returns true if the namespace is one of the tenant
+// namespaces, or any namespace is allowed. An empty namespace means all
+// namespaces in scope.
+func (o Options) IsNamespaceInScope(namespace string) bool {
+	return namespace == "" || len(o.TenantNamespaces) == 0 || slices.Contains(o.TenantNamespaces, namespace)
+}
+
 // Resource is a wrapper around all necessary clients and config used for endpoints
 type Resource struct {
-	Config    *rest.Config
-	K8sClient k8sclientset.Interface
-	Options   Options
+	Config             *rest.Config
+	K8sClient          k8sclientset.Interface
+	DynamicClient      dynamic.Interface
+	ExternalLogsClient *http.Client
+	WebResources       fs.FS
+	Announcements      *Announcements
+	AccessReviews      *AccessReviews
+	Clusters           *clusters.Registry
+	Runs               *runs.Index
+	Search             *search.Index
+	Stats              *stats.Index
+	WatchCache         *watchcache.Cache
+	Options            Options
+}
+
+// getExternalLogsClient returns the client configured for the external logs
+// provider, or the default client if none was provided
+func (r Resource) getExternalLogsClient() *http.Client {
+	if r.ExternalLogsClient != nil {
+		return r.ExternalLogsClient
+	}
+	return http.DefaultClient
 }
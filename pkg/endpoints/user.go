@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/tektoncd/dashboard/pkg/utils"
+	"k8s.io/client-go/dynamic"
+	k8sclientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// identityRoundTripper adds the user's identity headers to requests. The
+// Dashboard's own credentials are only used if the user did not provide any,
+// as for requests sent through the Kubernetes API proxy.
+type identityRoundTripper struct {
+	identity http.Header
+	next     http.RoundTripper
+}
+
+func (rt identityRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for key, values := range rt.identity {
+		req.Header[key] = values
+	}
+	return rt.next.RoundTrip(req)
+}
+
+// userHTTPClient returns an HTTP client adding the identity headers to
+// requests sent with the Dashboard's config
+func (r Resource) userHTTPClient(identity http.Header) (*http.Client, error) {
+	transport, err := rest.TransportFor(r.Config)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: identityRoundTripper{identity: identity, next: transport}}, nil
+}
+
+// userDynamicClient returns a client sending requests as the user sending the
+// request, so that they are authorised and audited as the user
+func (r Resource) userDynamicClient(request *http.Request) (dynamic.Interface, error) {
+	identity := utils.IdentityHeaders(request.Header)
+	if len(identity) == 0 {
+		return r.DynamicClient, nil
+	}
+	client, err := r.userHTTPClient(identity)
+	if err != nil {
+		return nil, err
+	}
+	return dynamic.NewForConfigAndClient(r.Config, client)
+}
+
+// userK8sClient returns a Kubernetes client sending requests as the user
+// sending the request, like userDynamicClient
+func (r Resource) userK8sClient(request *http.Request) (k8sclientset.Interface, error) {
+	identity := utils.IdentityHeaders(request.Header)
+	if len(identity) == 0 {
+		return r.K8sClient, nil
+	}
+	client, err := r.userHTTPClient(identity)
+	if err != nil {
+		return nil, err
+	}
+	return k8sclientset.NewForConfigAndClient(r.Config, client)
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externallogs
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+)
+
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateOpen
+	stateHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case stateClosed:
+		return "closed"
+	case stateOpen:
+		return "open"
+	case stateHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitOpenError is returned without contacting the external logs provider
+// while the circuit breaker is open
+type CircuitOpenError struct {
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("external logs provider is unavailable, retry in %s", e.RetryAfter.Round(time.Second))
+}
+
+// breakerRoundTripper stops sending requests to the provider after a number of
+// consecutive failures. Once resetTimeout has elapsed a single trial request is
+// allowed through, closing the circuit again if it succeeds.
+type breakerRoundTripper struct {
+	next             http.RoundTripper
+	failureThreshold int
+	resetTimeout     time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+func newBreakerRoundTripper(next http.RoundTripper, failureThreshold int, resetTimeout time.Duration) *breakerRoundTripper {
+	rt := &breakerRoundTripper{
+		next:             next,
+		failureThreshold: failureThreshold,
+		resetTimeout:     resetTimeout,
+	}
+	breakerStateGauge.Set(float64(stateClosed))
+	return rt
+}
+
+func (rt *breakerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := rt.allow(); err != nil {
+		breakerRejectedCounter.Inc()
+		return nil, err
+	}
+
+	resp, err := rt.next.RoundTrip(req)
+	if err != nil && req.Context().Err() != nil {
+		// The caller went away, this says nothing about the provider
+		rt.cancelTrial()
+		return resp, err
+	}
+	rt.record(err == nil && resp.StatusCode < http.StatusInternalServerError)
+	return resp, err
+}
+
+// cancelTrial allows another trial request if the current one was abandoned
+func (rt *breakerRoundTripper) cancelTrial() {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.trial = false
+}
+
+// allow returns an error if the request must not be sent to the provider
+func (rt *breakerRoundTripper) allow() error {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	switch rt.state {
+	case stateOpen:
+		if elapsed := time.Since(rt.openedAt); elapsed < rt.resetTimeout {
+			return &CircuitOpenError{RetryAfter: rt.resetTimeout - elapsed}
+		}
+		rt.setState(stateHalfOpen)
+		rt.trial = true
+		return nil
+	case stateHalfOpen:
+		if rt.trial {
+			// Only one trial request at a time
+			return &CircuitOpenError{RetryAfter: time.Second}
+		}
+		rt.trial = true
+		return nil
+	case stateClosed:
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of a request
+func (rt *breakerRoundTripper) record(success bool) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if success {
+		rt.failures = 0
+		rt.trial = false
+		if rt.state != stateClosed {
+			rt.setState(stateClosed)
+		}
+		return
+	}
+
+	rt.failures++
+	if rt.state == stateHalfOpen || rt.failures >= rt.failureThreshold {
+		rt.trial = false
+		rt.openedAt = time.Now()
+		if rt.state != stateOpen {
+			rt.setState(stateOpen)
+		}
+	}
+}
+
+func (rt *breakerRoundTripper) setState(state breakerState) {
+	logging.Log.Infof("External logs circuit breaker %s", state)
+	rt.state = state
+	breakerStateGauge.Set(float64(state))
+	breakerTransitionsCounter.WithLabelValues(state.String()).Inc()
+}
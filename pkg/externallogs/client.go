@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externallogs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Options for the client used to call the external logs provider
+type Options struct {
+	// TokenFile contains a bearer token sent with each request
+	TokenFile string
+	// BasicAuthDir contains username and password files, as mounted from a
+	// kubernetes.io/basic-auth Secret, used for basic authentication
+	BasicAuthDir string
+	// Headers are added to each request
+	Headers http.Header
+	// CAFile contains PEM encoded certificates used to verify the provider
+	CAFile string
+	// CertFile and KeyFile contain a PEM encoded client certificate and key
+	CertFile string
+	KeyFile  string
+	// DialTimeout limits the time taken to establish a connection
+	DialTimeout time.Duration
+	// ResponseHeaderTimeout limits the time waiting for the response headers,
+	// it does not limit the time taken to stream the response body
+	ResponseHeaderTimeout time.Duration
+	// MaxRetries is the number of times an idempotent request is retried
+	MaxRetries int
+	// BreakerFailureThreshold is the number of consecutive failures after
+	// which requests fail fast, 0 disables the circuit breaker
+	BreakerFailureThreshold int
+	// BreakerResetTimeout is the time requests fail fast before the provider
+	// is tried again
+	BreakerResetTimeout time.Duration
+}
+
+// ParseHeaders parses a list of headers in the form "Name: value"
+func ParseHeaders(headers []string) (http.Header, error) {
+	parsed := http.Header{}
+	for _, header := range headers {
+		name, value, found := strings.Cut(header, ":")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid header %q, expected 'Name: value'", header)
+		}
+		parsed.Add(textproto.CanonicalMIMEHeaderKey(name), strings.TrimSpace(value))
+	}
+	return parsed, nil
+}
+
+// NewClient returns a client for the external logs provider configured with the
+// provided credentials, headers, TLS settings, timeouts and failure handling.
+// Credential files are read again whenever they change so mounted Secrets can
+// be rotated without a restart.
+func NewClient(opts Options) (*http.Client, error) {
+	if opts.TokenFile != "" && opts.BasicAuthDir != "" {
+		return nil, errors.New("only one of a token file or basic auth credentials can be configured")
+	}
+	if (opts.CertFile == "") != (opts.KeyFile == "") {
+		return nil, errors.New("both a client certificate and key must be configured")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	tlsConfig, err := newTLSConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+	if opts.DialTimeout > 0 {
+		transport.DialContext = (&net.Dialer{
+			Timeout:   opts.DialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+	}
+	transport.ResponseHeaderTimeout = opts.ResponseHeaderTimeout
+
+	auth := &authRoundTripper{
+		next:    transport,
+		headers: opts.Headers,
+	}
+	if opts.TokenFile != "" {
+		auth.token = &watchedFile{path: opts.TokenFile}
+	}
+	if opts.BasicAuthDir != "" {
+		auth.username = &watchedFile{path: filepath.Join(opts.BasicAuthDir, "username")}
+		auth.password = &watchedFile{path: filepath.Join(opts.BasicAuthDir, "password")}
+	}
+
+	var rt http.RoundTripper = &retryRoundTripper{next: auth, maxRetries: opts.MaxRetries}
+	if opts.BreakerFailureThreshold > 0 {
+		// The breaker wraps the retries so a request failing after all its
+		// attempts only counts as a single failure
+		rt = newBreakerRoundTripper(rt, opts.BreakerFailureThreshold, opts.BreakerResetTimeout)
+	}
+
+	return &http.Client{Transport: rt}, nil
+}
+
+func newTLSConfig(opts Options) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if opts.CAFile != "" {
+		ca, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", opts.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if opts.CertFile != "" {
+		cert := &clientCertificate{
+			cert: &watchedFile{path: opts.CertFile},
+			key:  &watchedFile{path: opts.KeyFile},
+		}
+		// Fail early on an invalid certificate rather than on the first request
+		if _, err := cert.get(nil); err != nil {
+			return nil, err
+		}
+		tlsConfig.GetClientCertificate = cert.get
+	}
+
+	return tlsConfig, nil
+}
+
+// authRoundTripper adds the configured headers and credentials to each request
+type authRoundTripper struct {
+	next     http.RoundTripper
+	headers  http.Header
+	token    *watchedFile
+	username *watchedFile
+	password *watchedFile
+}
+
+func (rt *authRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, values := range rt.headers {
+		req.Header[name] = append([]string(nil), values...)
+	}
+
+	switch {
+	case rt.token != nil:
+		token, _, err := rt.token.read()
+		if err != nil {
+			return nil, fmt.Errorf("error reading external logs token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	case rt.username != nil:
+		username, _, err := rt.username.read()
+		if err != nil {
+			return nil, fmt.Errorf("error reading external logs username: %w", err)
+		}
+		password, _, err := rt.password.read()
+		if err != nil {
+			return nil, fmt.Errorf("error reading external logs password: %w", err)
+		}
+		req.SetBasicAuth(strings.TrimSpace(string(username)), strings.TrimSpace(string(password)))
+	}
+
+	return rt.next.RoundTrip(req)
+}
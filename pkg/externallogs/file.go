@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externallogs
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// watchedFile caches the content of a file, reading it again when its
+// modification time or size changes. Files mounted from a Secret are replaced
+// when the Secret is updated so this picks up rotated credentials.
+type watchedFile struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	content []byte
+}
+
+// read returns the content of the file and whether it changed since the last read
+func (f *watchedFile) read() ([]byte, bool, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.content != nil && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.content, false, nil
+	}
+
+	content, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, false, err
+	}
+	f.content = content
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+	return content, true, nil
+}
+
+// clientCertificate loads a client certificate, reloading it when either the
+// certificate or key file changes
+type clientCertificate struct {
+	cert *watchedFile
+	key  *watchedFile
+
+	mu     sync.Mutex
+	loaded *tls.Certificate
+}
+
+func (c *clientCertificate) get(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	certPEM, certChanged, err := c.cert.read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading client certificate: %w", err)
+	}
+	keyPEM, keyChanged, err := c.key.read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading client key: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.loaded != nil && !certChanged && !keyChanged {
+		return c.loaded, nil
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("error loading client certificate: %w", err)
+	}
+	c.loaded = &cert
+	return c.loaded, nil
+}
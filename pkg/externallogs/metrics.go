@@ -0,0 +1,43 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externallogs
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	breakerStateGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "tekton_dashboard",
+		Subsystem: "external_logs",
+		Name:      "circuit_breaker_state",
+		Help:      "State of the external logs provider circuit breaker (0: closed, 1: open, 2: half-open)",
+	})
+	breakerTransitionsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "tekton_dashboard",
+		Subsystem: "external_logs",
+		Name:      "circuit_breaker_transitions_total",
+		Help:      "Number of times the external logs provider circuit breaker changed to each state",
+	}, []string{"state"})
+	breakerRejectedCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "tekton_dashboard",
+		Subsystem: "external_logs",
+		Name:      "circuit_breaker_rejected_requests_total",
+		Help:      "Number of requests rejected without contacting the external logs provider because the circuit breaker was open",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(breakerStateGauge, breakerTransitionsCounter, breakerRejectedCounter)
+}
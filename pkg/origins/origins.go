@@ -0,0 +1,220 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package origins decides which origins may send requests to the Dashboard
+package origins
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// origin is an allowed origin, whose host may start with a wildcard label
+// matching any subdomain
+type origin struct {
+	scheme string
+	host   string
+	// wildcard is true if host is a domain whose subdomains are allowed
+	wildcard bool
+}
+
+// Policy decides whether the origin of a request is allowed. Requests are
+// allowed from the host they were sent to, or from the external URL if one
+// is configured, and from any of the allowed origins.
+type Policy struct {
+	externalURL    *origin
+	allowed        []origin
+	trustedProxies []netip.Prefix
+}
+
+// Normalize returns the scheme and host of a URL, omitting the default port
+func Normalize(u *url.URL) (string, string) {
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Host)
+	switch {
+	case scheme == "https" && strings.HasSuffix(host, ":443"):
+		host = strings.TrimSuffix(host, ":443")
+	case scheme == "http" && strings.HasSuffix(host, ":80"):
+		host = strings.TrimSuffix(host, ":80")
+	}
+	return scheme, host
+}
+
+// parseOrigin parses an origin such as https://dashboard.example.com or
+// https://*.example.com
+func parseOrigin(value string) (origin, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return origin{}, err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.Trim(u.Path, "/") != "" {
+		return origin{}, fmt.Errorf("invalid origin %q, expected a scheme and host such as https://dashboard.example.com", value)
+	}
+	scheme, host := Normalize(u)
+	o := origin{scheme: scheme, host: host}
+	if domain, found := strings.CutPrefix(host, "*."); found {
+		if domain == "" || strings.Contains(domain, "*") {
+			return origin{}, fmt.Errorf("invalid origin %q, only a leading wildcard label is supported", value)
+		}
+		o.host, o.wildcard = domain, true
+	} else if strings.Contains(host, "*") {
+		return origin{}, fmt.Errorf("invalid origin %q, only a leading wildcard label is supported", value)
+	}
+	return o, nil
+}
+
+func (o origin) matches(scheme, host string) bool {
+	if o.scheme != scheme {
+		return false
+	}
+	if o.wildcard {
+		return strings.HasSuffix(host, "."+o.host)
+	}
+	return host == o.host
+}
+
+// NewPolicy returns a Policy allowing the given origins, optionally with a
+// wildcard subdomain, and trusting X-Forwarded-Host when sent by proxies in
+// the given CIDRs. If externalURL is set, it replaces the host of the request
+// as the Dashboard's own origin.
+func NewPolicy(externalURL string, allowedOrigins, trustedProxies []string) (*Policy, error) {
+	p := &Policy{}
+	if externalURL != "" {
+		u, err := url.Parse(externalURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid external URL %q, expected a scheme and host", externalURL)
+		}
+		scheme, host := Normalize(u)
+		p.externalURL = &origin{scheme: scheme, host: host}
+	}
+	for _, value := range allowedOrigins {
+		o, err := parseOrigin(value)
+		if err != nil {
+			return nil, err
+		}
+		p.allowed = append(p.allowed, o)
+	}
+	for _, value := range trustedProxies {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(value)
+			if addrErr != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q, expected a CIDR or IP address", value)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		p.trustedProxies = append(p.trustedProxies, prefix.Masked())
+	}
+	return p, nil
+}
+
+func (p *Policy) isTrustedAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range p.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func remoteAddr(r *http.Request) (netip.Addr, error) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return netip.ParseAddr(host)
+}
+
+// IsTrustedProxy returns true if the request was sent by a trusted proxy, so
+// headers set by the proxy can be relied on
+func (p *Policy) IsTrustedProxy(r *http.Request) bool {
+	if len(p.trustedProxies) == 0 {
+		return false
+	}
+	addr, err := remoteAddr(r)
+	return err == nil && p.isTrustedAddr(addr)
+}
+
+// ClientIP returns the address of the client which sent the request. For
+// requests sent by a trusted proxy this is the last address in X-Forwarded-For
+// which is not a trusted proxy, as earlier addresses may be set by the client.
+func (p *Policy) ClientIP(r *http.Request) string {
+	addr, err := remoteAddr(r)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	if !p.IsTrustedProxy(r) {
+		return addr.Unmap().String()
+	}
+	forwarded := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		forwardedAddr, err := netip.ParseAddr(strings.TrimSpace(forwarded[i]))
+		if err != nil {
+			break
+		}
+		addr = forwardedAddr
+		if !p.isTrustedAddr(addr) {
+			break
+		}
+	}
+	return addr.Unmap().String()
+}
+
+// IsTLSRequest returns true if the request was received over TLS, either
+// directly or by a trusted proxy which set X-Forwarded-Proto
+func (p *Policy) IsTLSRequest(r *http.Request) bool {
+	if r.TLS != nil {
+		return true
+	}
+	if !p.IsTrustedProxy(r) {
+		return false
+	}
+	// Use the first value, added by the proxy closest to the client
+	proto, _, _ := strings.Cut(r.Header.Get("X-Forwarded-Proto"), ",")
+	return strings.EqualFold(strings.TrimSpace(proto), "https")
+}
+
+// RequestHost returns the host the client sent the request to, which is the
+// X-Forwarded-Host header if the request was sent by a trusted proxy
+func (p *Policy) RequestHost(r *http.Request) string {
+	if p.IsTrustedProxy(r) {
+		// Use the first value, added by the proxy closest to the client
+		if forwarded, _, _ := strings.Cut(r.Header.Get("X-Forwarded-Host"), ","); strings.TrimSpace(forwarded) != "" {
+			return strings.TrimSpace(forwarded)
+		}
+	}
+	return r.Host
+}
+
+// IsAllowed returns true if a request from the given origin is allowed
+func (p *Policy) IsAllowed(r *http.Request, u *url.URL) bool {
+	scheme, host := Normalize(u)
+	if p.externalURL != nil {
+		if p.externalURL.matches(scheme, host) {
+			return true
+		}
+	} else if strings.EqualFold(u.Host, p.RequestHost(r)) {
+		return true
+	}
+	for _, o := range p.allowed {
+		if o.matches(scheme, host) {
+			return true
+		}
+	}
+	return false
+}
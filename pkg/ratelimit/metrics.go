@@ -0,0 +1,29 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var rejectedRequestsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "tekton_dashboard",
+	Subsystem: "rate_limit",
+	Name:      "rejected_requests_total",
+	Help:      "Number of requests rejected because a client exceeded the rate or concurrency limit of the request class",
+}, []string{"class", "reason"})
+
+func init() {
+	prometheus.MustRegister(rejectedRequestsCounter)
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit limits the rate and concurrency of requests from each
+// client of the Dashboard
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	"golang.org/x/time/rate"
+)
+
+// Class groups requests with a similar cost to the API server
+type Class string
+
+// Request classes
+const (
+	ClassList   Class = "list"
+	ClassWatch  Class = "watch"
+	ClassLog    Class = "log"
+	ClassMutate Class = "mutate"
+)
+
+const (
+	// idleTimeout is how long the state of a client is kept after its last request
+	idleTimeout = 10 * time.Minute
+	// concurrencyRetryAfter is suggested to clients rejected by a concurrency
+	// cap, as there is no way to know when a request will complete
+	concurrencyRetryAfter = 5 * time.Second
+)
+
+// Limits configures the rate limit and concurrency cap of a request class.
+// Zero values disable the corresponding limit.
+type Limits struct {
+	// QPS is the sustained number of requests per second
+	QPS float64
+	// Burst is the number of requests allowed above the sustained rate
+	Burst int
+	// Concurrent is the number of requests which may be in progress at once
+	Concurrent int
+}
+
+// ParseLimits parses limits in the form qps=20,burst=40,concurrent=10. Omitted
+// values are unlimited.
+func ParseLimits(spec string) (Limits, error) {
+	var limits Limits
+	for _, field := range strings.Split(spec, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		key, value, found := strings.Cut(field, "=")
+		if !found {
+			return limits, fmt.Errorf("invalid limit %q, expected key=value", field)
+		}
+		var err error
+		switch strings.TrimSpace(key) {
+		case "qps":
+			limits.QPS, err = strconv.ParseFloat(value, 64)
+		case "burst":
+			limits.Burst, err = strconv.Atoi(value)
+		case "concurrent":
+			limits.Concurrent, err = strconv.Atoi(value)
+		default:
+			return limits, fmt.Errorf("unknown limit %q, expected qps, burst or concurrent", key)
+		}
+		if err != nil {
+			return limits, fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+	}
+	if limits.QPS < 0 || limits.Burst < 0 || limits.Concurrent < 0 {
+		return limits, fmt.Errorf("invalid limits %q, values must not be negative", spec)
+	}
+	return limits, nil
+}
+
+// Classify returns the class of a request
+func Classify(r *http.Request) Class {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+	default:
+		return ClassMutate
+	}
+
+	query := r.URL.Query()
+	watch := query.Get("watch")
+	if strings.EqualFold(r.Header.Get("Connection"), "upgrade") || watch == "true" || watch == "1" ||
+		strings.Contains(r.URL.Path, "/watch/") || isEventStream(r) {
+		return ClassWatch
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	if strings.HasSuffix(path, "/log") || strings.HasSuffix(path, "/logs") || strings.HasSuffix(path, "/logs.zip") ||
+		strings.Contains(path, "/logs-proxy/") {
+		return ClassLog
+	}
+
+	return ClassList
+}
+
+// isEventStream returns true for long-lived Server-Sent Events streams
+func isEventStream(r *http.Request) bool {
+	return strings.TrimSuffix(r.URL.Path, "/") == "/v1/announcements/stream" ||
+		strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+// LimitExceededError is returned when a request exceeds a limit
+type LimitExceededError struct {
+	Class      Class
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%s %s limit exceeded, retry after %s", e.Class, e.Reason, e.RetryAfter)
+}
+
+type clientKey struct {
+	client string
+	class  Class
+}
+
+type clientState struct {
+	limiter  *rate.Limiter
+	active   int
+	lastSeen time.Time
+}
+
+// Limiter enforces per client limits for each class of requests
+type Limiter struct {
+	limits map[Class]Limits
+	key    func(*http.Request) string
+
+	mu        sync.Mutex
+	clients   map[clientKey]*clientState
+	lastSweep time.Time
+}
+
+// New returns a Limiter enforcing the given limits for each client, identified
+// by the key function
+func New(limits map[Class]Limits, key func(*http.Request) string) *Limiter {
+	return &Limiter{
+		limits:  limits,
+		key:     key,
+		clients: map[clientKey]*clientState{},
+	}
+}
+
+// sweep forgets clients which are idle, the caller must hold the lock
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+	for key, state := range l.clients {
+		if state.active == 0 && now.Sub(state.lastSeen) > idleTimeout {
+			delete(l.clients, key)
+		}
+	}
+}
+
+// acquire admits a request from the client, returning a function to call
+// when the request completes
+func (l *Limiter) acquire(client string, class Class, limits Limits) (func(), error) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+
+	key := clientKey{client: client, class: class}
+	state, ok := l.clients[key]
+	if !ok {
+		state = &clientState{}
+		if limits.QPS > 0 {
+			burst := limits.Burst
+			if burst == 0 {
+				burst = int(math.Ceil(limits.QPS))
+			}
+			state.limiter = rate.NewLimiter(rate.Limit(limits.QPS), burst)
+		}
+		l.clients[key] = state
+	}
+	state.lastSeen = now
+
+	if limits.Concurrent > 0 && state.active >= limits.Concurrent {
+		return nil, &LimitExceededError{Class: class, Reason: "concurrency", RetryAfter: concurrencyRetryAfter}
+	}
+	if state.limiter != nil {
+		reservation := state.limiter.ReserveN(now, 1)
+		if delay := reservation.DelayFrom(now); delay > 0 {
+			reservation.CancelAt(now)
+			return nil, &LimitExceededError{Class: class, Reason: "rate", RetryAfter: delay}
+		}
+	}
+
+	state.active++
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		state.active--
+		state.lastSeen = time.Now()
+	}, nil
+}
+
+// Wrap returns a handler enforcing the limits before calling h. Requests
+// exceeding a limit are rejected with a 429 Too Many Requests response.
+func (l *Limiter) Wrap(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		class := Classify(r)
+		limits, ok := l.limits[class]
+		if !ok || limits == (Limits{}) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		client := l.key(r)
+		release, err := l.acquire(client, class, limits)
+		var exceeded *LimitExceededError
+		if errors.As(err, &exceeded) {
+			rejectedRequestsCounter.WithLabelValues(string(class), exceeded.Reason).Inc()
+			logging.Log.Debugf("Rejecting request from %s: %s", client, err.Error())
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(exceeded.RetryAfter.Seconds()))))
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+		defer release()
+
+		h.ServeHTTP(w, r)
+	})
+}
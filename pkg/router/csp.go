@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/dashboard/pkg/endpoints"
+)
+
+type cspNonceKey struct{}
+
+var htmlNonceElementPattern = regexp.MustCompile(`(?i)<(script|style)(\s|>)`)
+
+// newCSPNonce returns a random value for use as a Content-Security-Policy nonce
+func newCSPNonce() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func withCSPNonce(ctx context.Context, nonce string) context.Context {
+	return context.WithValue(ctx, cspNonceKey{}, nonce)
+}
+
+func getCSPNonce(ctx context.Context) string {
+	nonce, _ := ctx.Value(cspNonceKey{}).(string)
+	return nonce
+}
+
+// buildCSP returns the Content-Security-Policy for the web UI, allowing scripts
+// and optionally styles carrying the given nonce
+func buildCSP(opts endpoints.ContentSecurityPolicyOptions, frameAncestors []string, nonce string) string {
+	nonceSource := "'nonce-" + nonce + "'"
+	inlineStyleSource := "'unsafe-inline'"
+	if opts.StyleNonce {
+		inlineStyleSource = nonceSource
+	}
+
+	directives := [][]string{
+		{"default-src", "'none'"},
+		slices.Concat([]string{"img-src", "'self'"}, opts.ImgSrc),
+		slices.Concat([]string{"script-src", "'self'", nonceSource}, opts.ScriptSrc),
+		slices.Concat([]string{"style-src", "'self'", inlineStyleSource}, opts.StyleSrc),
+		slices.Concat([]string{"connect-src", "'self'", "wss:", "ws:"}, opts.ConnectSrc),
+		slices.Concat([]string{"font-src", "'self'"}, opts.FontSrc),
+	}
+	if len(frameAncestors) > 0 {
+		directives = append(directives, slices.Concat([]string{"frame-ancestors"}, frameAncestors))
+	}
+	if opts.ReportURI != "" {
+		directives = append(directives, []string{"report-uri", opts.ReportURI})
+	}
+	if opts.ReportTo != "" {
+		directives = append(directives, []string{"report-to", opts.ReportTo})
+	}
+
+	policy := make([]string, 0, len(directives))
+	for _, directive := range directives {
+		policy = append(policy, strings.Join(directive, " "))
+	}
+	return strings.Join(policy, "; ") + ";"
+}
+
+// reportingEndpoints returns the value of the Reporting-Endpoints header
+// declaring the endpoint used by the report-to directive
+func reportingEndpoints(opts endpoints.ContentSecurityPolicyOptions) string {
+	if opts.ReportTo == "" || opts.ReportURI == "" {
+		return ""
+	}
+	return opts.ReportTo + "=" + strconv.Quote(opts.ReportURI)
+}
+
+// addNonce adds the nonce to each script and style element of an HTML document,
+// and exposes it to scripts loaded by the document in a csp-nonce meta element
+func addNonce(document []byte, nonce string) []byte {
+	attr := ` nonce="` + nonce + `"`
+	document = htmlNonceElementPattern.ReplaceAll(document, []byte("<${1}"+attr+"${2}"))
+
+	loc := htmlHeadPattern.FindIndex(document)
+	if loc == nil {
+		return document
+	}
+	meta := []byte(`<meta property="csp-nonce"` + attr + `>`)
+	return slices.Concat(document[:loc[1]], meta, document[loc[1]:])
+}
@@ -14,52 +14,30 @@ limitations under the License.
 package router
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net"
 	"net/http"
 	"net/url"
-	"regexp"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/tektoncd/dashboard/pkg/clusters"
 	"github.com/tektoncd/dashboard/pkg/csrf"
 	"github.com/tektoncd/dashboard/pkg/endpoints"
 	logging "github.com/tektoncd/dashboard/pkg/logging"
+	"github.com/tektoncd/dashboard/pkg/origins"
+	"github.com/tektoncd/dashboard/pkg/ratelimit"
+	"github.com/tektoncd/dashboard/pkg/watchcache"
 	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/proxy"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/transport"
 )
 
-const webResourcesDir = "/var/run/ko"
-
-var webResourcesStaticPattern = regexp.MustCompile(`^/([[:alnum:]]+\.)?[[:alnum:]]+\.(js)|(css)|(png)$`)
-var webResourcesStaticExcludePattern = regexp.MustCompile("^/favicon.png$")
-
-func registerWeb(resource endpoints.Resource, mux *http.ServeMux) {
-	logging.Log.Info("Adding Web API")
-
-	fs := http.FileServer(http.Dir(webResourcesDir))
-	mux.HandleFunc("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if webResourcesStaticPattern.Match([]byte(r.URL.Path)) && !webResourcesStaticExcludePattern.Match([]byte(r.URL.Path)) {
-			// Static resources are immutable and have a content hash in their URL
-			w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
-		}
-
-		w.Header().Set("Content-Security-Policy", "default-src 'none'; img-src 'self'; script-src 'self'; style-src 'self' 'unsafe-inline'; connect-src 'self' wss: ws:; font-src 'self' https://1.www.s81c.com;")
-
-		switch resource.Options.XFrameOptions {
-		case "": // Do nothing, no X-Frame-Options header
-		case "SAMEORIGIN":
-			w.Header().Set("X-Frame-Options", "SAMEORIGIN")
-		default:
-			w.Header().Set("X-Frame-Options", "DENY")
-		}
-		fs.ServeHTTP(w, r)
-	}))
-}
-
 // registerHealthProbe registers the /health endpoint
 func registerHealthProbe(r endpoints.Resource, mux *http.ServeMux) {
 	logging.Log.Info("Adding API for health")
@@ -72,6 +50,12 @@ func registerReadinessProbe(r endpoints.Resource, mux *http.ServeMux) {
 	mux.HandleFunc("/readiness", r.CheckHealth)
 }
 
+// registerMetrics registers the /metrics endpoint
+func registerMetrics(mux *http.ServeMux) {
+	logging.Log.Info("Adding API for metrics")
+	mux.Handle("/metrics", promhttp.Handler())
+}
+
 // registerPropertiesEndpoint adds the endpoint for obtaining any properties we
 // want to serve.
 func registerPropertiesEndpoint(r endpoints.Resource, mux *http.ServeMux) {
@@ -79,6 +63,139 @@ func registerPropertiesEndpoint(r endpoints.Resource, mux *http.ServeMux) {
 	mux.HandleFunc("/v1/properties", r.GetProperties)
 }
 
+// registerLogStreams adds the endpoints for streaming step logs as Server-Sent Events
+func registerLogStreams(r endpoints.Resource, mux *http.ServeMux) {
+	logging.Log.Info("Adding API for log streams")
+	mux.HandleFunc("GET /v1/namespaces/{namespace}/pods/{pod}/containers/{container}/logs", r.StreamStepLogs)
+	mux.HandleFunc("GET /v1/namespaces/{namespace}/taskruns/{name}/logs", r.StreamTaskRunLogs)
+}
+
+// registerLogsArchive adds the endpoint for downloading all logs of a PipelineRun
+func registerLogsArchive(r endpoints.Resource, mux *http.ServeMux) {
+	logging.Log.Info("Adding API for logs archive")
+	mux.HandleFunc("GET /v1/pipelineruns/{namespace}/{name}/logs.zip", r.DownloadPipelineRunLogs)
+}
+
+// registerExport adds the endpoints for exporting resources without their
+// runtime fields
+func registerExport(r endpoints.Resource, mux *http.ServeMux) {
+	logging.Log.Info("Adding API for exporting resources")
+	mux.HandleFunc("GET /v1/export/{group}/{version}/{resource}/{namespace}", r.ExportResources)
+	mux.HandleFunc("GET /v1/export/{group}/{version}/{resource}/{namespace}/{name}", r.ExportResources)
+}
+
+// registerRerun adds the endpoints for rerunning PipelineRuns and TaskRuns,
+// unless the Dashboard is read-only
+func registerRerun(r endpoints.Resource, mux *http.ServeMux) {
+	if r.Options.ReadOnly {
+		return
+	}
+	logging.Log.Info("Adding API for rerunning runs")
+	mux.HandleFunc("POST /v1/pipelineruns/{namespace}/{name}/rerun", r.RerunPipelineRun)
+	mux.HandleFunc("POST /v1/taskruns/{namespace}/{name}/rerun", r.RerunTaskRun)
+}
+
+// registerStartRun adds the endpoint for starting a Pipeline, unless the
+// Dashboard is read-only
+func registerStartRun(r endpoints.Resource, mux *http.ServeMux) {
+	if r.Options.ReadOnly {
+		return
+	}
+	logging.Log.Info("Adding API for starting Pipelines")
+	mux.HandleFunc("POST /v1/pipelines/{namespace}/{name}/runs", r.StartPipelineRun)
+}
+
+// registerBulkActions adds the endpoint for cancelling, deleting, pausing and
+// resuming runs in bulk, unless the Dashboard is read-only
+func registerBulkActions(r endpoints.Resource, mux *http.ServeMux) {
+	if r.Options.ReadOnly {
+		return
+	}
+	logging.Log.Info("Adding API for bulk actions")
+	mux.HandleFunc("POST /v1/bulk", r.BulkUpdateRuns)
+}
+
+// registerCSPReportEndpoint adds the endpoint receiving Content-Security-Policy violation reports
+func registerCSPReportEndpoint(r endpoints.Resource, mux *http.ServeMux) {
+	logging.Log.Info("Adding API for CSP reports")
+	mux.HandleFunc("POST "+cspReportPath, r.ReportCSPViolation)
+}
+
+// registerAnnouncements adds the endpoints for retrieving and streaming announcements
+func registerAnnouncements(r endpoints.Resource, mux *http.ServeMux) {
+	logging.Log.Info("Adding API for announcements")
+	mux.HandleFunc("GET /v1/announcements", r.GetAnnouncements)
+	mux.HandleFunc("GET /v1/announcements/stream", r.StreamAnnouncements)
+}
+
+// registerBrandingAssets adds the endpoint serving the assets of a custom branding
+func registerBrandingAssets(r endpoints.Resource, mux *http.ServeMux) {
+	if r.Options.BrandingDir != "" {
+		logging.Log.Info("Adding API for branding assets")
+		mux.HandleFunc("GET "+endpoints.BrandingAssetsPath+"{name}", r.GetBrandingAsset)
+	}
+}
+
+// registerStats adds the endpoint for aggregated run statistics
+func registerStats(r endpoints.Resource, mux *http.ServeMux) {
+	if r.Stats != nil {
+		logging.Log.Info("Adding API for run statistics")
+		mux.HandleFunc("GET /v1/stats", r.GetStats)
+	}
+}
+
+// registerRuns adds the endpoint for querying runs from the run index
+func registerRuns(r endpoints.Resource, mux *http.ServeMux) {
+	if r.Runs != nil {
+		logging.Log.Info("Adding API for runs")
+		mux.HandleFunc("GET /v1/runs", r.GetRuns)
+	}
+}
+
+// registerSearch adds the endpoint for searching Tekton resources
+func registerSearch(r endpoints.Resource, mux *http.ServeMux) {
+	if r.Search != nil {
+		logging.Log.Info("Adding API for search")
+		mux.HandleFunc("GET /v1/search", r.GetSearch)
+	}
+}
+
+// registerClusters adds the endpoints describing the managed clusters and
+// proxying requests to their API servers under /clusters/{cluster}
+func registerClusters(r endpoints.Resource, policy *origins.Policy, localProxy http.Handler, mux *http.ServeMux) {
+	if r.Clusters == nil {
+		return
+	}
+	logging.Log.Info("Adding API for clusters")
+	mux.HandleFunc("GET /v1/clusters", r.GetClusters)
+	mux.HandleFunc("GET /v1/clusters/{cluster}/properties", r.GetClusterProperties)
+	mux.HandleFunc("GET /v1/clusters/{cluster}/health", r.GetClusterHealth)
+
+	newProxyHandler := func(clusterConfig *rest.Config) (http.Handler, error) {
+		return NewProxyHandler(clusterConfig, 30*time.Second, policy, nil)
+	}
+	clusterProxy := func(w http.ResponseWriter, req *http.Request) {
+		name := req.PathValue("cluster")
+		cluster, ok := r.Clusters.Get(name)
+		if !ok {
+			http.Error(w, "cluster not found", http.StatusNotFound)
+			return
+		}
+		handler := localProxy
+		if cluster.Name != clusters.Local {
+			var err error
+			if handler, err = cluster.Handler(newProxyHandler); err != nil {
+				logging.Log.Errorf("Error creating proxy for cluster %s: %s", name, err.Error())
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+		}
+		http.StripPrefix("/clusters/"+name, handler).ServeHTTP(w, req)
+	}
+	mux.HandleFunc("/clusters/{cluster}/api/", clusterProxy)
+	mux.HandleFunc("/clusters/{cluster}/apis/", clusterProxy)
+}
+
 func registerLogsProxy(r endpoints.Resource, mux *http.ServeMux) {
 	if r.Options.ExternalLogsURL != "" {
 		logging.Log.Info("Adding API for logs proxy")
@@ -86,9 +203,21 @@ func registerLogsProxy(r endpoints.Resource, mux *http.ServeMux) {
 	}
 }
 
+const (
+	// cspReportPath receives violation reports, which browsers send without the CSRF header
+	cspReportPath = "/v1/csp-report"
+	// csrfTokenPath serves the CSRF token when using the token mode
+	csrfTokenPath = "/v1/csrf-token"
+	// minCSRFSecretSize is the minimum size of a secret used to sign CSRF tokens
+	minCSRFSecretSize = 32
+)
+
 // Server is a http.Handler which proxies Kubernetes APIs to the API server.
 type Server struct {
-	handler http.Handler
+	handler         http.Handler
+	csrfOptions     []csrf.Option
+	securityHeaders endpoints.SecurityHeadersOptions
+	policy          *origins.Policy
 }
 
 type responder struct{}
@@ -127,7 +256,11 @@ func Register(r endpoints.Resource, cfg *rest.Config) (*Server, error) {
 	logging.Log.Info("Adding Kube API")
 	apiProxyPrefix := "/api/"
 	apisProxyPrefix := "/apis/"
-	proxyHandler, err := NewProxyHandler(cfg, 30*time.Second)
+	policy, err := origins.NewPolicy(r.Options.ExternalURL, r.Options.AllowedOrigins, r.Options.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	proxyHandler, err := NewProxyHandler(cfg, 30*time.Second, policy, r.WatchCache)
 	if err != nil {
 		return nil, err
 	}
@@ -136,17 +269,140 @@ func Register(r endpoints.Resource, cfg *rest.Config) (*Server, error) {
 	mux.Handle(apisProxyPrefix, proxyHandler)
 
 	logging.Log.Info("Adding Dashboard APIs")
-	registerWeb(r, mux)
+	if err := registerWeb(r, mux); err != nil {
+		return nil, err
+	}
 	registerPropertiesEndpoint(r, mux)
 	registerHealthProbe(r, mux)
 	registerReadinessProbe(r, mux)
+	registerMetrics(mux)
+	registerLogStreams(r, mux)
+	registerLogsArchive(r, mux)
+	registerExport(r, mux)
+	registerRerun(r, mux)
+	registerStartRun(r, mux)
+	registerBulkActions(r, mux)
 	registerLogsProxy(r, mux)
+	registerCSPReportEndpoint(r, mux)
+	registerBrandingAssets(r, mux)
+	registerAnnouncements(r, mux)
+	registerClusters(r, policy, proxyHandler, mux)
+	registerStats(r, mux)
+	registerRuns(r, mux)
+	registerSearch(r, mux)
+
+	csrfOptions, err := getCSRFOptions(r.Options, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	handler, err := limitAPIRequests(r.Options.RateLimit, policy, mux)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		handler:         mountOnBasePath(r.Options.BasePath, handler),
+		csrfOptions:     csrfOptions,
+		securityHeaders: r.Options.SecurityHeaders,
+		policy:          policy,
+	}, nil
+}
+
+// getCSRFOptions returns the options configuring the CSRF protection
+func getCSRFOptions(opts endpoints.Options, policy *origins.Policy) ([]csrf.Option, error) {
+	csrfOptions := []csrf.Option{csrf.ExemptPath(opts.BasePath + cspReportPath)}
+
+	switch opts.CSRF.Mode {
+	case "", csrf.ModeHeader:
+		return csrfOptions, nil
+	case csrf.ModeToken:
+	default:
+		return nil, fmt.Errorf("unsupported CSRF mode %q", opts.CSRF.Mode)
+	}
+
+	var secret []byte
+	var err error
+	if opts.CSRF.SecretFile == "" {
+		logging.Log.Warn("No CSRF secret file provided, tokens will not be valid across restarts or replicas")
+		secret, err = csrf.NewSecret()
+	} else {
+		secret, err = os.ReadFile(opts.CSRF.SecretFile)
+		secret = bytes.TrimSpace(secret)
+		if err == nil && len(secret) < minCSRFSecretSize {
+			err = fmt.Errorf("CSRF secret must be at least %d bytes", minCSRFSecretSize)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	return &Server{handler: mux}, nil
+	logging.Log.Info("Using token-based CSRF protection")
+	return append(csrfOptions, csrf.TokenMode(secret, opts.BasePath+csrfTokenPath, opts.BasePath+"/", policy)), nil
 }
 
-// NewProxyHandler creates an API proxy handler for the cluster
-func NewProxyHandler(cfg *rest.Config, keepalive time.Duration) (http.Handler, error) {
+// limitAPIRequests applies the configured rate limits and concurrency caps to
+// API requests, which may result in requests to the API server
+func limitAPIRequests(opts endpoints.RateLimitOptions, policy *origins.Policy, h http.Handler) (http.Handler, error) {
+	if !opts.Enabled {
+		return h, nil
+	}
+
+	limits := map[ratelimit.Class]ratelimit.Limits{}
+	for class, spec := range map[ratelimit.Class]string{
+		ratelimit.ClassList:   opts.List,
+		ratelimit.ClassWatch:  opts.Watch,
+		ratelimit.ClassLog:    opts.Log,
+		ratelimit.ClassMutate: opts.Mutate,
+	} {
+		classLimits, err := ratelimit.ParseLimits(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s rate limit: %w", class, err)
+		}
+		limits[class] = classLimits
+	}
+
+	limiter := ratelimit.New(limits, func(r *http.Request) string {
+		// The user header can be set by any client, so is only used for
+		// requests sent by a trusted proxy
+		if opts.UserHeader != "" && policy.IsTrustedProxy(r) {
+			if user := r.Header.Get(opts.UserHeader); user != "" {
+				return "user:" + user
+			}
+		}
+		return "ip:" + policy.ClientIP(r)
+	})
+	limited := limiter.Wrap(h)
+
+	logging.Log.Info("Enabling rate limits for API requests")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, prefix := range []string{"/api/", "/apis/", "/clusters/", "/v1/"} {
+			if strings.HasPrefix(r.URL.Path, prefix) {
+				limited.ServeHTTP(w, r)
+				return
+			}
+		}
+		h.ServeHTTP(w, r)
+	}), nil
+}
+
+// mountOnBasePath serves the handler under the given base path, redirecting
+// requests for the base path itself to include the trailing slash
+func mountOnBasePath(basePath string, h http.Handler) http.Handler {
+	if basePath == "" {
+		return h
+	}
+
+	logging.Log.Infof("Serving under base path %s", basePath)
+	mux := http.NewServeMux()
+	mux.Handle(basePath+"/", http.StripPrefix(basePath, h))
+	mux.Handle(basePath, http.RedirectHandler(basePath+"/", http.StatusMovedPermanently))
+	return mux
+}
+
+// NewProxyHandler creates an API proxy handler for the cluster, serving list
+// and watch requests from the watch cache if one is provided
+func NewProxyHandler(cfg *rest.Config, keepalive time.Duration, policy *origins.Policy, watchCache *watchcache.Cache) (http.Handler, error) {
 	host := cfg.Host
 	if !strings.HasSuffix(host, "/") {
 		host += "/"
@@ -169,8 +425,15 @@ func NewProxyHandler(cfg *rest.Config, keepalive time.Duration) (http.Handler, e
 	proxy.UpgradeTransport = upgradeTransport
 	proxy.UseRequestLocation = true
 	proxy.UseLocationHost = true
+	// Keep the path of API servers exposed under a prefix, e.g. by a gateway
+	// in front of a remote cluster
+	proxy.AppendLocationPath = true
 
-	proxyServer := protectWebSocket(proxy)
+	var handler http.Handler = proxy
+	if watchCache != nil {
+		handler = watchCache.Wrap(handler)
+	}
+	proxyServer := protectWebSocket(policy, handler)
 
 	return proxyServer, nil
 }
@@ -183,10 +446,12 @@ func (s *Server) Listen(address string, port int) (net.Listener, error) {
 
 // ServeOnListener starts the server using given listener, loops forever.
 func (s *Server) ServeOnListener(l net.Listener) error {
-	CSRF := csrf.Protect()
+	CSRF := csrf.Protect(s.csrfOptions...)
 
 	server := http.Server{
-		Handler:           CSRF(s.handler),
+		// Security headers are added outermost so they are also set on
+		// responses rejected by the CSRF protection
+		Handler:           protectSecurityHeaders(s.securityHeaders, s.policy, CSRF(s.handler)),
 		ReadHeaderTimeout: 30 * time.Second,
 	}
 	return server.Serve(l)
@@ -198,8 +463,9 @@ func isUpgradeRequest(req *http.Request) bool {
 	return strings.ToLower(connection) == "upgrade"
 }
 
-func checkUpgradeSameOrigin(req *http.Request) bool {
-	host := req.Host
+// checkUpgradeOrigin returns true if the request is not an upgrade request or
+// its origin is allowed by the policy
+func checkUpgradeOrigin(policy *origins.Policy, req *http.Request) bool {
 	origin := req.Header.Get("Origin")
 
 	if len(origin) == 0 || !isUpgradeRequest(req) {
@@ -211,17 +477,17 @@ func checkUpgradeSameOrigin(req *http.Request) bool {
 		return false
 	}
 
-	return u.Host == host
+	return policy.IsAllowed(req, u)
 }
 
 // Verify Origin header on Upgrade requests to prevent cross-origin websocket hijacking
-func protectWebSocket(h http.Handler) http.Handler {
+func protectWebSocket(policy *origins.Policy, h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		reqURL := req.URL.RequestURI()
 		reqURL = strings.ReplaceAll(reqURL, "\n", "")
 		reqURL = strings.ReplaceAll(reqURL, "\r", "")
 		logging.Log.Debugf("Proxying request: %s %s %s", req.RemoteAddr, req.Method, reqURL)
-		if !checkUpgradeSameOrigin(req) {
+		if !checkUpgradeOrigin(policy, req) {
 			origin := req.Header.Get("Origin")
 			origin = strings.ReplaceAll(origin, "\n", "")
 			origin = strings.ReplaceAll(origin, "\r", "")
@@ -0,0 +1,57 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/tektoncd/dashboard/pkg/endpoints"
+	"github.com/tektoncd/dashboard/pkg/origins"
+)
+
+// protectSecurityHeaders adds the configured security headers to every response.
+// The web UI replaces the Content-Security-Policy with its full policy which
+// includes the same frame-ancestors directive.
+func protectSecurityHeaders(opts endpoints.SecurityHeadersOptions, policy *origins.Policy, h http.Handler) http.Handler {
+	headers := map[string]string{
+		"Referrer-Policy":              opts.ReferrerPolicy,
+		"Permissions-Policy":           opts.PermissionsPolicy,
+		"X-Content-Type-Options":       opts.ContentTypeOptions,
+		"Cross-Origin-Opener-Policy":   opts.CrossOriginOpenerPolicy,
+		"Cross-Origin-Resource-Policy": opts.CrossOriginResourcePolicy,
+	}
+	switch opts.XFrameOptions {
+	case "": // Do nothing, no X-Frame-Options header
+	case "SAMEORIGIN":
+		headers["X-Frame-Options"] = "SAMEORIGIN"
+	default:
+		headers["X-Frame-Options"] = "DENY"
+	}
+	if frameAncestors := opts.GetFrameAncestors(); len(frameAncestors) > 0 {
+		headers["Content-Security-Policy"] = "frame-ancestors " + strings.Join(frameAncestors, " ") + ";"
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for name, value := range headers {
+			if value != "" {
+				w.Header().Set(name, value)
+			}
+		}
+		if opts.StrictTransportSecurity != "" && policy.IsTLSRequest(r) {
+			w.Header().Set("Strict-Transport-Security", opts.StrictTransportSecurity)
+		}
+		h.ServeHTTP(w, r)
+	})
+}
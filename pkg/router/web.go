@@ -0,0 +1,344 @@
+/*
+Copyright 2019-2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"html"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/dashboard/pkg/endpoints"
+	logging "github.com/tektoncd/dashboard/pkg/logging"
+)
+
+var webResourcesStaticPattern = regexp.MustCompile(`^/([[:alnum:]]+\.)?[[:alnum:]]+\.(js)|(css)|(png)$`)
+var webResourcesStaticExcludePattern = regexp.MustCompile("^/favicon.png$")
+
+var (
+	htmlBasePattern = regexp.MustCompile(`(?i)<base\s[^>]*>`)
+	htmlHeadPattern = regexp.MustCompile(`(?i)<head(\s[^>]*)?>`)
+
+	htmlTitlePattern = regexp.MustCompile(`(?is)<title>.*?</title>`)
+	htmlIconPattern  = regexp.MustCompile(`(?i)<link\s[^>]*rel="icon"[^>]*>`)
+)
+
+// apiPathPrefixes are never served the web UI in place of a missing resource
+var apiPathPrefixes = []string{"/api/", "/apis/", "/clusters/", "/v1/", "/health", "/readiness", "/metrics"}
+
+// Precompressed variants of the web resources, in order of preference
+var webEncodings = []struct {
+	name      string
+	extension string
+}{
+	{name: "br", extension: ".br"},
+	{name: "gzip", extension: ".gz"},
+}
+
+// webFile describes a web resource and the precompressed variants available for it
+type webFile struct {
+	etag     string
+	modTime  time.Time
+	variants map[string]webVariant
+	// document is set for index.html, which is rendered for each request
+	// with a new nonce instead of being served from the file system
+	document []byte
+}
+
+type webVariant struct {
+	name string
+	etag string
+}
+
+// webHandler serves the web resources, using a precompressed variant of each
+// resource when the client accepts it. ETags are computed from the content of
+// each resource when the handler is created so conditional requests can be
+// answered with a 304 Not Modified.
+type webHandler struct {
+	files fs.FS
+	index map[string]*webFile
+	// dirs are the directories containing web resources
+	dirs map[string]bool
+	// fallback is index.html with its base URL set so relative URLs resolve
+	// correctly when it is served in place of a missing resource
+	fallback *webFile
+}
+
+func computeETag(files fs.FS, name string) (string, error) {
+	f, err := files.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return `"` + hex.EncodeToString(hash.Sum(nil))[:32] + `"`, nil
+}
+
+// setBaseHref sets the base URL of an HTML document, replacing any existing base element
+func setBaseHref(document []byte, href string) []byte {
+	base := []byte(`<base href="` + html.EscapeString(href) + `">`)
+	if htmlBasePattern.Match(document) {
+		return htmlBasePattern.ReplaceAllLiteral(document, base)
+	}
+	loc := htmlHeadPattern.FindIndex(document)
+	if loc == nil {
+		return document
+	}
+	return slices.Concat(document[:loc[1]], base, document[loc[1]:])
+}
+
+// applyBranding sets the title and favicon of an HTML document to those of
+// the configured branding
+func applyBranding(document []byte, branding endpoints.Branding) []byte {
+	if branding.Title != "" {
+		title := []byte("<title>" + html.EscapeString(branding.Title) + "</title>")
+		document = htmlTitlePattern.ReplaceAllLiteral(document, title)
+	}
+	if branding.FaviconURL != "" {
+		icon := []byte(`<link rel="icon" href="` + html.EscapeString(branding.FaviconURL) + `" />`)
+		document = htmlIconPattern.ReplaceAllLiteral(document, icon)
+	}
+	return document
+}
+
+// loadIndex returns index.html with the configured branding, and with its base
+// URL set to the base path the Dashboard is served from if setBase is true
+func (h *webHandler) loadIndex(opts endpoints.Options, setBase bool) (*webFile, error) {
+	document, err := fs.ReadFile(h.files, "index.html")
+	if err != nil {
+		return nil, err
+	}
+	document = applyBranding(document, opts.Branding)
+	if setBase {
+		document = setBaseHref(document, opts.BasePath+"/")
+	}
+	return &webFile{document: document}, nil
+}
+
+func newWebHandler(files fs.FS, opts endpoints.Options) (*webHandler, error) {
+	h := &webHandler{
+		files: files,
+		index: map[string]*webFile{},
+		dirs:  map[string]bool{},
+	}
+
+	var compressed []string
+	err := fs.WalkDir(files, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		h.dirs[path.Dir(name)] = true
+		for _, encoding := range webEncodings {
+			if strings.HasSuffix(name, encoding.extension) {
+				compressed = append(compressed, name)
+				return nil
+			}
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		etag, err := computeETag(files, name)
+		if err != nil {
+			return err
+		}
+		h.index[name] = &webFile{etag: etag, modTime: info.ModTime(), variants: map[string]webVariant{}}
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	for _, name := range compressed {
+		for _, encoding := range webEncodings {
+			original, found := strings.CutSuffix(name, encoding.extension)
+			if !found {
+				continue
+			}
+			file, ok := h.index[original]
+			if !ok {
+				continue
+			}
+			etag, err := computeETag(files, name)
+			if err != nil {
+				return nil, err
+			}
+			file.variants[encoding.name] = webVariant{name: name, etag: etag}
+		}
+	}
+
+	if _, ok := h.index["index.html"]; ok {
+		index, err := h.loadIndex(opts, opts.BasePath != "")
+		if err != nil {
+			return nil, err
+		}
+		h.index["index.html"] = index
+		if h.fallback, err = h.loadIndex(opts, true); err != nil {
+			return nil, err
+		}
+	}
+
+	return h, nil
+}
+
+// acceptsEncoding returns true if the Accept-Encoding header allows the given
+// content coding, either explicitly or through a wildcard
+func acceptsEncoding(header, coding string) bool {
+	accepted, wildcard := -1.0, -1.0
+	for _, value := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(value, ";")
+		name = strings.TrimSpace(name)
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if found && strings.EqualFold(key, "q") {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		switch {
+		case strings.EqualFold(name, coding):
+			accepted = q
+		case name == "*":
+			wildcard = q
+		}
+	}
+	if accepted >= 0 {
+		return accepted > 0
+	}
+	return wildcard > 0
+}
+
+// isHistoryFallback returns true if the request for the named resource should
+// be served index.html when no resource matches its path, so the UI can handle
+// routes itself. Requests for missing files in the directories of the web
+// resources, e.g. assets of a previous release, or APIs are excluded so they
+// still return a 404. Other paths may contain a dot, e.g. a run named
+// build-v1.2.
+func (h *webHandler) isHistoryFallback(r *http.Request, name string) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if path.Ext(name) != "" && h.dirs[path.Dir(name)] {
+		return false
+	}
+	for _, prefix := range apiPathPrefixes {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *webHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := path.Clean(r.URL.Path)
+	if strings.HasSuffix(r.URL.Path, "/") {
+		name = path.Join(name, "index.html")
+	}
+	name = strings.TrimPrefix(name, "/")
+
+	file, ok := h.index[name]
+	if !ok && h.fallback != nil && h.isHistoryFallback(r, name) {
+		name, file, ok = "index.html", h.fallback, true
+	}
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	servedName, etag := name, file.etag
+	if len(file.variants) > 0 {
+		w.Header().Add("Vary", "Accept-Encoding")
+		for _, encoding := range webEncodings {
+			if variant, ok := file.variants[encoding.name]; ok && acceptsEncoding(r.Header.Get("Accept-Encoding"), encoding.name) {
+				servedName, etag = variant.name, variant.etag
+				w.Header().Set("Content-Encoding", encoding.name)
+				break
+			}
+		}
+	}
+
+	if contentType := mime.TypeByExtension(path.Ext(name)); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+
+	if file.document != nil {
+		// The document contains a nonce matching the Content-Security-Policy
+		// of this response only so it must not be cached
+		w.Header().Set("Cache-Control", "no-store")
+		document := addNonce(file.document, getCSPNonce(r.Context()))
+		http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(document))
+		return
+	}
+
+	w.Header().Set("ETag", etag)
+
+	f, err := h.files.Open(servedName)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	content, ok := f.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(f)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		content = bytes.NewReader(data)
+	}
+	http.ServeContent(w, r, name, file.modTime, content)
+}
+
+func registerWeb(resource endpoints.Resource, mux *http.ServeMux) error {
+	logging.Log.Info("Adding Web API")
+
+	web, err := newWebHandler(resource.WebResources, resource.Options)
+	if err != nil {
+		return err
+	}
+	mux.HandleFunc("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if webResourcesStaticPattern.Match([]byte(r.URL.Path)) && !webResourcesStaticExcludePattern.Match([]byte(r.URL.Path)) {
+			// Static resources are immutable and have a content hash in their URL
+			w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		}
+
+		nonce := newCSPNonce()
+		w.Header().Set("Content-Security-Policy", buildCSP(resource.Options.ContentSecurityPolicy, resource.Options.SecurityHeaders.GetFrameAncestors(), nonce))
+		if reporting := reportingEndpoints(resource.Options.ContentSecurityPolicy); reporting != "" {
+			w.Header().Set("Reporting-Endpoints", reporting)
+		}
+		web.ServeHTTP(w, r.WithContext(withCSPNonce(r.Context(), nonce)))
+	}))
+	return nil
+}
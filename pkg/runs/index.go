@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package runs keeps an in-memory index of PipelineRuns and TaskRuns, kept up
+// to date by informers, for queries which would otherwise require listing all
+// runs from the API server
+package runs
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Kind is a kind of run
+type Kind string
+
+// Kinds of runs
+const (
+	KindPipelineRun Kind = "PipelineRun"
+	KindTaskRun     Kind = "TaskRun"
+)
+
+// pipelineIndex indexes runs by the name of their Pipeline or Task
+const pipelineIndex = "pipeline"
+
+type kindInfo struct {
+	gvr schema.GroupVersionResource
+	// label holding the name of the run's Pipeline or Task
+	label string
+}
+
+var kinds = map[Kind]kindInfo{
+	KindPipelineRun: {
+		gvr:   schema.GroupVersionResource{Group: "tekton.dev", Version: "v1", Resource: "pipelineruns"},
+		label: "tekton.dev/pipeline",
+	},
+	KindTaskRun: {
+		gvr:   schema.GroupVersionResource{Group: "tekton.dev", Version: "v1", Resource: "taskruns"},
+		label: "tekton.dev/task",
+	},
+}
+
+// GroupVersion returns the API group and version of the kind of run
+func (k Kind) GroupVersion() schema.GroupVersion {
+	return kinds[k].gvr.GroupVersion()
+}
+
+// GroupVersionResource returns the resource of the kind of run
+func (k Kind) GroupVersionResource() schema.GroupVersionResource {
+	return kinds[k].gvr
+}
+
+// Index holds the runs in the namespaces in scope
+type Index struct {
+	factories []dynamicinformer.DynamicSharedInformerFactory
+	informers map[Kind][]cache.SharedIndexInformer
+	synced    atomic.Bool
+}
+
+// New returns an Index of the runs in the given namespaces, or all
+// namespaces if none are provided. Call Run to start filling the index.
+func New(client dynamic.Interface, namespaces []string) *Index {
+	idx := &Index{informers: map[Kind][]cache.SharedIndexInformer{}}
+	scopes := namespaces
+	if len(scopes) == 0 {
+		scopes = []string{metav1.NamespaceAll}
+	}
+	for _, namespace := range scopes {
+		idx.factories = append(idx.factories, dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, 0, namespace, nil))
+	}
+	for kind, info := range kinds {
+		for _, factory := range idx.factories {
+			idx.informers[kind] = append(idx.informers[kind], factory.ForResource(info.gvr).Informer())
+		}
+	}
+	return idx
+}
+
+// Run fills the index and keeps it up to date until the context is done
+func (idx *Index) Run(ctx context.Context) error {
+	var synced []cache.InformerSynced
+	for kind, informers := range idx.informers {
+		label := kinds[kind].label
+		for _, informer := range informers {
+			// Only keep the fields needed to list runs in memory
+			if err := informer.SetTransform(transform); err != nil {
+				return err
+			}
+			// Dynamic informers already index objects by namespace
+			if err := informer.AddIndexers(cache.Indexers{
+				pipelineIndex: func(obj any) ([]string, error) {
+					u, ok := obj.(*unstructured.Unstructured)
+					if !ok {
+						return nil, nil
+					}
+					return []string{pipelineName(u, label)}, nil
+				},
+			}); err != nil {
+				return err
+			}
+			synced = append(synced, informer.HasSynced)
+		}
+	}
+
+	for _, factory := range idx.factories {
+		factory.Start(ctx.Done())
+	}
+	if cache.WaitForCacheSync(ctx.Done(), synced...) {
+		idx.synced.Store(true)
+		logging.Log.Info("Run index synced")
+	}
+	<-ctx.Done()
+	for _, factory := range idx.factories {
+		factory.Shutdown()
+	}
+	return nil
+}
+
+// HasSynced returns true once the index holds all runs
+func (idx *Index) HasSynced() bool {
+	return idx.synced.Load()
+}
+
+// transform strips a run of the fields not needed to list runs, such as its
+// embedded spec and the status of its children and steps
+func transform(obj any) (any, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return obj, nil
+	}
+	metadata, _, _ := unstructured.NestedMap(u.Object, "metadata")
+	delete(metadata, "managedFields")
+	if annotations, ok := metadata["annotations"].(map[string]any); ok {
+		delete(annotations, "kubectl.kubernetes.io/last-applied-configuration")
+	}
+	stripped := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": u.GetAPIVersion(),
+		"kind":       u.GetKind(),
+		"metadata":   metadata,
+	}}
+	for _, field := range [][]string{
+		{"spec", "pipelineRef"},
+		{"spec", "taskRef"},
+		{"spec", "status"},
+		{"status", "conditions"},
+		{"status", "startTime"},
+		{"status", "completionTime"},
+		{"status", "podName"},
+	} {
+		if value, found, _ := unstructured.NestedFieldNoCopy(u.Object, field...); found {
+			_ = unstructured.SetNestedField(stripped.Object, value, field...)
+		}
+	}
+	return stripped, nil
+}
+
+// List returns the runs of the given kind, optionally in a namespace and of a
+// Pipeline or Task. The runs are shared with the index and must not be modified.
+func (idx *Index) List(kind Kind, namespace, pipeline string) []*unstructured.Unstructured {
+	var list []*unstructured.Unstructured
+	for _, informer := range idx.informers[kind] {
+		var objs []any
+		switch {
+		case pipeline != "":
+			objs, _ = informer.GetIndexer().ByIndex(pipelineIndex, pipeline)
+		case namespace != "":
+			objs, _ = informer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+		default:
+			objs = informer.GetStore().List()
+		}
+		for _, obj := range objs {
+			u, ok := obj.(*unstructured.Unstructured)
+			if !ok || (namespace != "" && u.GetNamespace() != namespace) {
+				continue
+			}
+			list = append(list, u)
+		}
+	}
+	return list
+}
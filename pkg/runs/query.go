@@ -0,0 +1,219 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runs
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Fields runs can be sorted by
+const (
+	SortStartTime = "startTime"
+	SortDuration  = "duration"
+)
+
+const (
+	// DefaultLimit is the number of runs returned if no limit is requested
+	DefaultLimit = 100
+	// MaxLimit is the maximum number of runs returned at once
+	MaxLimit = 1000
+)
+
+var (
+	// ErrNotSynced is returned when the index has not finished its initial list
+	ErrNotSynced = errors.New("the run index is not available yet")
+	// ErrInvalidContinue is returned for a continue token which does not
+	// match the query
+	ErrInvalidContinue = errors.New("invalid continue token")
+)
+
+// Query selects a page of runs
+type Query struct {
+	Kind      Kind
+	Namespace string
+	// Pipeline restricts the runs to those of the named Pipeline or Task
+	Pipeline string
+	Labels   labels.Selector
+	// Statuses restricts the runs to those with any of the statuses
+	Statuses []Status
+	// Since and Until restrict the runs to those started in this range, or
+	// created if they have not started
+	Since time.Time
+	Until time.Time
+	// Sort is the field runs are sorted by, startTime by default
+	Sort string
+	// Ascending sorts runs from the oldest or shortest, instead of the newest
+	// or longest
+	Ascending bool
+	Limit     int
+	// Continue is the token returned with the previous page
+	Continue string
+	// Allowed restricts the runs to the namespaces the user may list, if set
+	Allowed func(namespace string) bool
+}
+
+// Page is a page of runs matching a query
+type Page struct {
+	Items []*unstructured.Unstructured
+	// Continue is the token to request the next page, empty on the last page
+	Continue string
+	// Remaining is the number of matching runs after this page
+	Remaining int
+}
+
+// ParseSort validates the field runs are sorted by
+func ParseSort(value string) (string, error) {
+	switch value {
+	case "":
+		return SortStartTime, nil
+	case SortStartTime, SortDuration:
+		return value, nil
+	default:
+		return "", fmt.Errorf("invalid sort %q, expected %s or %s", value, SortStartTime, SortDuration)
+	}
+}
+
+// sortKey orders runs by the sort field. Runs without a value for the field,
+// such as runs which have not completed when sorting by duration, are always
+// sorted last. Ties are broken by namespace and name.
+type sortKey struct {
+	Missing bool   `json:"m,omitempty"`
+	Value   int64  `json:"v"`
+	Name    string `json:"n"`
+}
+
+// cursor is the position of the last run of a page, encoded in continue tokens
+type cursor struct {
+	Sort      string  `json:"s"`
+	Ascending bool    `json:"a,omitempty"`
+	After     sortKey `json:"k"`
+}
+
+func (q Query) sortKey(s Summary) sortKey {
+	key := sortKey{Name: s.Namespace + "/" + s.Name}
+	switch q.Sort {
+	case SortDuration:
+		duration, ok := s.Duration()
+		key.Value, key.Missing = int64(duration), !ok
+	default:
+		key.Value = s.StartTime().UnixNano()
+	}
+	return key
+}
+
+func (q Query) compare(a, b sortKey) int {
+	if a.Missing != b.Missing {
+		if a.Missing {
+			return 1
+		}
+		return -1
+	}
+	value := cmp.Compare(a.Value, b.Value)
+	if !q.Ascending {
+		value = -value
+	}
+	return cmp.Or(value, strings.Compare(a.Name, b.Name))
+}
+
+func (q Query) matches(s Summary, u *unstructured.Unstructured) bool {
+	if len(q.Statuses) > 0 && !slices.Contains(q.Statuses, s.Status) {
+		return false
+	}
+	start := s.StartTime()
+	if !q.Since.IsZero() && start.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !start.Before(q.Until) {
+		return false
+	}
+	return q.Labels == nil || q.Labels.Matches(labels.Set(u.GetLabels()))
+}
+
+// decodeContinue decodes the continue token into c, returning false on the
+// first page
+func (q Query) decodeContinue(c *cursor) (bool, error) {
+	if q.Continue == "" {
+		return false, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(q.Continue)
+	if err != nil {
+		return false, ErrInvalidContinue
+	}
+	if err := json.Unmarshal(data, c); err != nil || c.Sort != q.Sort || c.Ascending != q.Ascending {
+		return false, ErrInvalidContinue
+	}
+	return true, nil
+}
+
+func (q Query) encodeContinue(after sortKey) string {
+	data, _ := json.Marshal(cursor{Sort: q.Sort, Ascending: q.Ascending, After: after})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Query returns the page of runs matching the query, after the position in
+// its continue token. Pages are stable as runs are added or removed, as the
+// token holds the sort key of the last run returned rather than an offset.
+func (idx *Index) Query(q Query) (Page, error) {
+	if !idx.HasSynced() {
+		return Page{}, ErrNotSynced
+	}
+	var after cursor
+	hasCursor, err := q.decodeContinue(&after)
+	if err != nil {
+		return Page{}, err
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+
+	type match struct {
+		key sortKey
+		run *unstructured.Unstructured
+	}
+	var matches []match
+	for _, u := range idx.List(q.Kind, q.Namespace, q.Pipeline) {
+		s := Summarize(q.Kind, u)
+		if !q.matches(s, u) || (q.Allowed != nil && !q.Allowed(s.Namespace)) {
+			continue
+		}
+		key := q.sortKey(s)
+		if hasCursor && q.compare(key, after.After) <= 0 {
+			continue
+		}
+		matches = append(matches, match{key: key, run: u})
+	}
+	slices.SortFunc(matches, func(a, b match) int { return q.compare(a.key, b.key) })
+
+	var page Page
+	for _, m := range matches[:min(limit, len(matches))] {
+		page.Items = append(page.Items, m.run)
+	}
+	if len(matches) > limit {
+		page.Continue = q.encodeContinue(matches[limit-1].key)
+		page.Remaining = len(matches) - limit
+	}
+	return page, nil
+}
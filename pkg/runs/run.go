@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runs
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Status is the outcome of a run
+type Status string
+
+// Run statuses
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// cancelledReasons are the reasons of the Succeeded condition of runs which
+// were cancelled rather than failed
+var cancelledReasons = map[string]bool{
+	"Cancelled":            true,
+	"CancelledRunFinally":  true,
+	"StoppedRunFinally":    true,
+	"PipelineRunCancelled": true,
+	"TaskRunCancelled":     true,
+}
+
+// ParseStatus validates the status of a run
+func ParseStatus(value string) (Status, error) {
+	switch status := Status(value); status {
+	case StatusPending, StatusRunning, StatusSucceeded, StatusFailed, StatusCancelled:
+		return status, nil
+	default:
+		return "", fmt.Errorf("invalid status %q, expected %s, %s, %s, %s or %s", value,
+			StatusPending, StatusRunning, StatusSucceeded, StatusFailed, StatusCancelled)
+	}
+}
+
+// ParseKind returns the kind of run from its resource or kind name
+func ParseKind(value string) (Kind, error) {
+	switch value {
+	case "", "pipelineruns", string(KindPipelineRun):
+		return KindPipelineRun, nil
+	case "taskruns", string(KindTaskRun):
+		return KindTaskRun, nil
+	default:
+		return "", fmt.Errorf("invalid kind %q, expected pipelineruns or taskruns", value)
+	}
+}
+
+// Summary holds the fields of a run used to filter, sort and aggregate runs
+type Summary struct {
+	Namespace string
+	Name      string
+	// Pipeline is the name of the Pipeline or Task the run executes, or the
+	// run's own name if its spec is embedded
+	Pipeline  string
+	Status    Status
+	Created   time.Time
+	Started   time.Time
+	Completed time.Time
+}
+
+// Summarize returns the summary of a run
+func Summarize(kind Kind, u *unstructured.Unstructured) Summary {
+	s := Summary{
+		Namespace: u.GetNamespace(),
+		Name:      u.GetName(),
+		Pipeline:  pipelineName(u, kinds[kind].label),
+		Created:   u.GetCreationTimestamp().Time,
+		Started:   parseTime(u, "startTime"),
+		Completed: parseTime(u, "completionTime"),
+	}
+	s.Status = runStatus(u, s.Started)
+	return s
+}
+
+// Duration returns the time from the start to the completion of the run, or
+// false if it has not completed
+func (s Summary) Duration() (time.Duration, bool) {
+	if s.Started.IsZero() || s.Completed.IsZero() {
+		return 0, false
+	}
+	return s.Completed.Sub(s.Started), true
+}
+
+// StartTime returns the time the run started, or was created if it has not
+// started yet
+func (s Summary) StartTime() time.Time {
+	if s.Started.IsZero() {
+		return s.Created
+	}
+	return s.Started
+}
+
+func pipelineName(u *unstructured.Unstructured, label string) string {
+	if name := u.GetLabels()[label]; name != "" {
+		return name
+	}
+	return u.GetName()
+}
+
+func parseTime(u *unstructured.Unstructured, field string) time.Time {
+	value, _, _ := unstructured.NestedString(u.Object, "status", field)
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// runStatus returns the status of a run from its Succeeded condition
+func runStatus(u *unstructured.Unstructured, started time.Time) Status {
+	value, _, _ := unstructured.NestedFieldNoCopy(u.Object, "status", "conditions")
+	conditions, _ := value.([]any)
+	for _, c := range conditions {
+		condition, ok := c.(map[string]any)
+		if !ok || condition["type"] != "Succeeded" {
+			continue
+		}
+		switch condition["status"] {
+		case "True":
+			return StatusSucceeded
+		case "False":
+			if reason, _ := condition["reason"].(string); cancelledReasons[reason] {
+				return StatusCancelled
+			}
+			return StatusFailed
+		}
+	}
+	if started.IsZero() {
+		return StatusPending
+	}
+	return StatusRunning
+}
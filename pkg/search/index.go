@@ -0,0 +1,357 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package search keeps an in-memory inverted index of Tekton resources, kept
+// up to date by informers, for full-text search
+package search
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// maxValueLength limits the length of the values indexed, e.g. of large
+// annotations or results
+const maxValueLength = 1024
+
+// Resource is a kind of resource which can be searched
+type Resource struct {
+	GVR  schema.GroupVersionResource
+	Kind string
+}
+
+// Resources are the resources indexed, if installed in the cluster
+var Resources = []Resource{
+	{GVR: schema.GroupVersionResource{Group: "tekton.dev", Version: "v1", Resource: "pipelines"}, Kind: "Pipeline"},
+	{GVR: schema.GroupVersionResource{Group: "tekton.dev", Version: "v1", Resource: "tasks"}, Kind: "Task"},
+	{GVR: schema.GroupVersionResource{Group: "tekton.dev", Version: "v1", Resource: "pipelineruns"}, Kind: "PipelineRun"},
+	{GVR: schema.GroupVersionResource{Group: "tekton.dev", Version: "v1", Resource: "taskruns"}, Kind: "TaskRun"},
+	{GVR: schema.GroupVersionResource{Group: "triggers.tekton.dev", Version: "v1beta1", Resource: "eventlisteners"}, Kind: "EventListener"},
+	{GVR: schema.GroupVersionResource{Group: "triggers.tekton.dev", Version: "v1beta1", Resource: "triggers"}, Kind: "Trigger"},
+}
+
+// Field is a field of a resource which is searched
+type Field uint8
+
+// Fields searched, in order of relevance
+const (
+	FieldName Field = 1 << iota
+	FieldLabels
+	FieldParams
+	FieldResults
+	FieldAnnotations
+)
+
+var fieldNames = []struct {
+	field  Field
+	name   string
+	weight float64
+}{
+	{FieldName, "name", 10},
+	{FieldLabels, "labels", 5},
+	{FieldParams, "params", 3},
+	{FieldResults, "results", 3},
+	{FieldAnnotations, "annotations", 1},
+}
+
+// ignoredAnnotations are not indexed as they duplicate the resource
+var ignoredAnnotations = map[string]bool{
+	"kubectl.kubernetes.io/last-applied-configuration": true,
+}
+
+// docKey identifies an indexed resource
+type docKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// Index is an inverted index from the terms found in resources to the fields
+// of each resource containing them
+type Index struct {
+	client     dynamic.Interface
+	discovery  discovery.DiscoveryInterface
+	namespaces []string
+
+	mu sync.RWMutex
+	// terms maps each term to the resources containing it, and the fields of
+	// each resource it was found in
+	terms map[string]map[docKey]Field
+	// words holds the terms in order, to find the terms starting with a
+	// prefix. It is sorted again by the next search once terms are added or
+	// removed, instead of on each update.
+	words      []string
+	wordsStale bool
+	// docs holds the terms of each resource, to remove them on update
+	docs      map[docKey][]string
+	resources map[string]Resource
+
+	synced atomic.Bool
+}
+
+// New returns an Index of the resources in the given namespaces, or all
+// namespaces if none are provided. Call Run to start filling the index.
+func New(client dynamic.Interface, discovery discovery.DiscoveryInterface, namespaces []string) *Index {
+	return &Index{
+		client:     client,
+		discovery:  discovery,
+		namespaces: namespaces,
+		terms:      map[string]map[docKey]Field{},
+		docs:       map[docKey][]string{},
+		resources:  map[string]Resource{},
+	}
+}
+
+// installedResources returns the indexed resources installed in the cluster
+func (idx *Index) installedResources() []Resource {
+	var installed []Resource
+	for _, resource := range Resources {
+		list, err := idx.discovery.ServerResourcesForGroupVersion(resource.GVR.GroupVersion().String())
+		if err != nil {
+			logging.Log.Debugf("Not indexing %s: %s", resource.GVR.Resource, err.Error())
+			continue
+		}
+		if slices.ContainsFunc(list.APIResources, func(r metav1.APIResource) bool { return r.Name == resource.GVR.Resource }) {
+			installed = append(installed, resource)
+		}
+	}
+	return installed
+}
+
+// Run fills the index and keeps it up to date until the context is done
+func (idx *Index) Run(ctx context.Context) error {
+	scopes := idx.namespaces
+	if len(scopes) == 0 {
+		scopes = []string{metav1.NamespaceAll}
+	}
+	var factories []dynamicinformer.DynamicSharedInformerFactory
+	for _, namespace := range scopes {
+		factories = append(factories, dynamicinformer.NewFilteredDynamicSharedInformerFactory(idx.client, 0, namespace, nil))
+	}
+
+	var synced []cache.InformerSynced
+	for _, resource := range idx.installedResources() {
+		idx.mu.Lock()
+		idx.resources[resource.Kind] = resource
+		idx.mu.Unlock()
+		for _, factory := range factories {
+			informer := factory.ForResource(resource.GVR).Informer()
+			// Only keep the fields which are searched in memory
+			if err := informer.SetTransform(transform); err != nil {
+				return err
+			}
+			if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+				AddFunc:    func(obj any) { idx.update(resource.Kind, obj) },
+				UpdateFunc: func(_, obj any) { idx.update(resource.Kind, obj) },
+				DeleteFunc: func(obj any) { idx.remove(resource.Kind, obj) },
+			}); err != nil {
+				return err
+			}
+			synced = append(synced, informer.HasSynced)
+		}
+	}
+
+	for _, factory := range factories {
+		factory.Start(ctx.Done())
+	}
+	if cache.WaitForCacheSync(ctx.Done(), synced...) {
+		idx.synced.Store(true)
+		logging.Log.Info("Search index synced")
+	}
+	<-ctx.Done()
+	for _, factory := range factories {
+		factory.Shutdown()
+	}
+	return nil
+}
+
+// HasSynced returns true once the index holds all resources
+func (idx *Index) HasSynced() bool {
+	return idx.synced.Load()
+}
+
+// searchedFields are the fields of a resource kept by transform
+var searchedFields = [][]string{
+	{"spec", "params"},
+	{"status", "results"},
+	{"status", "pipelineResults"},
+}
+
+// transform strips a resource of the fields which are not searched
+func transform(obj any) (any, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return obj, nil
+	}
+	stripped := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": u.GetAPIVersion(),
+		"kind":       u.GetKind(),
+	}}
+	stripped.SetNamespace(u.GetNamespace())
+	stripped.SetName(u.GetName())
+	stripped.SetUID(u.GetUID())
+	stripped.SetResourceVersion(u.GetResourceVersion())
+	stripped.SetLabels(u.GetLabels())
+	stripped.SetAnnotations(u.GetAnnotations())
+	for _, field := range searchedFields {
+		if value, found, _ := unstructured.NestedFieldNoCopy(u.Object, field...); found {
+			_ = unstructured.SetNestedField(stripped.Object, value, field...)
+		}
+	}
+	return stripped, nil
+}
+
+// Tokenize splits text into lower case terms of letters and digits
+func Tokenize(text string) []string {
+	if len(text) > maxValueLength {
+		text = text[:maxValueLength]
+	}
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// valueText returns the text of a param or result value, which may be a
+// string, an array, or an object
+func valueText(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, valueText(item))
+		}
+		return strings.Join(parts, " ")
+	case map[string]any:
+		parts := make([]string, 0, 2*len(v))
+		for key, item := range v {
+			parts = append(parts, key, valueText(item))
+		}
+		return strings.Join(parts, " ")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// namedValues returns the text of a list of params or results, including
+// their names, values and defaults
+func namedValues(u *unstructured.Unstructured, field ...string) []string {
+	value, _, _ := unstructured.NestedFieldNoCopy(u.Object, field...)
+	items, _ := value.([]any)
+	var texts []string
+	for _, item := range items {
+		entry, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		for _, key := range []string{"name", "value", "default"} {
+			if text := valueText(entry[key]); text != "" {
+				texts = append(texts, text)
+			}
+		}
+	}
+	return texts
+}
+
+// fieldTerms returns the terms found in each field of a resource
+func fieldTerms(u *unstructured.Unstructured) map[string]Field {
+	terms := map[string]Field{}
+	add := func(field Field, texts ...string) {
+		for _, text := range texts {
+			for _, term := range Tokenize(text) {
+				terms[term] |= field
+			}
+		}
+	}
+
+	add(FieldName, u.GetName())
+	for key, value := range u.GetLabels() {
+		add(FieldLabels, key, value)
+	}
+	for key, value := range u.GetAnnotations() {
+		if !ignoredAnnotations[key] {
+			add(FieldAnnotations, key, value)
+		}
+	}
+	add(FieldParams, namedValues(u, "spec", "params")...)
+	add(FieldResults, namedValues(u, "status", "results")...)
+	add(FieldResults, namedValues(u, "status", "pipelineResults")...)
+	return terms
+}
+
+// removeLocked removes a resource from the index, the caller must hold the lock
+func (idx *Index) removeLocked(key docKey) {
+	for _, term := range idx.docs[key] {
+		delete(idx.terms[term], key)
+		if len(idx.terms[term]) == 0 {
+			delete(idx.terms, term)
+			idx.wordsStale = true
+		}
+	}
+	delete(idx.docs, key)
+}
+
+func (idx *Index) update(kind string, obj any) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	key := docKey{kind: kind, namespace: u.GetNamespace(), name: u.GetName()}
+	terms := fieldTerms(u)
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.removeLocked(key)
+	list := make([]string, 0, len(terms))
+	for term, fields := range terms {
+		docs, ok := idx.terms[term]
+		if !ok {
+			docs = map[docKey]Field{}
+			idx.terms[term] = docs
+			idx.wordsStale = true
+		}
+		docs[key] = fields
+		list = append(list, term)
+	}
+	idx.docs[key] = list
+}
+
+func (idx *Index) remove(kind string, obj any) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.removeLocked(docKey{kind: kind, namespace: u.GetNamespace(), name: u.GetName()})
+}
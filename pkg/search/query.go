@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package search
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// DefaultLimit is the number of results returned if no limit is requested
+	DefaultLimit = 20
+	// MaxLimit is the maximum number of results returned at once
+	MaxLimit = 200
+	// prefixWeight scales the score of terms matching the start of a word,
+	// so that whole words rank first
+	prefixWeight = 0.5
+)
+
+var (
+	// ErrNotSynced is returned when the index has not finished its initial list
+	ErrNotSynced = errors.New("the search index is not available yet")
+	// ErrEmptyQuery is returned for a query without any term
+	ErrEmptyQuery = errors.New("the query must contain at least one letter or digit")
+)
+
+// Query selects the resources to search
+type Query struct {
+	// Text is the text searched, resources must match each of its terms
+	Text string
+	// Kinds restricts the results to these kinds of resources
+	Kinds []string
+	// Namespace restricts the results to this namespace, all if empty
+	Namespace string
+	Limit     int
+	// Allowed restricts the results to the resources and namespaces the
+	// user may list, if set. It is called without holding the index lock.
+	Allowed func(gvr schema.GroupVersionResource, namespace string) bool
+}
+
+// Result is a resource matching a query
+type Result struct {
+	Kind       string  `json:"kind"`
+	APIVersion string  `json:"apiVersion"`
+	Namespace  string  `json:"namespace"`
+	Name       string  `json:"name"`
+	Score      float64 `json:"score"`
+	// Matches lists the fields containing the terms searched
+	Matches []string `json:"matches"`
+}
+
+// Results holds the best results of a query
+type Results struct {
+	// Total is the number of resources matching the query, including those
+	// beyond the limit
+	Total   int      `json:"total"`
+	Results []Result `json:"results"`
+}
+
+// ParseKinds validates the kinds of resources to search, given by kind or
+// resource name, e.g. PipelineRun or pipelineruns
+func ParseKinds(values []string) ([]string, error) {
+	var kinds []string
+	for _, value := range values {
+		i := slices.IndexFunc(Resources, func(r Resource) bool {
+			return strings.EqualFold(value, r.Kind) || strings.EqualFold(value, r.GVR.Resource)
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("invalid kind %q", value)
+		}
+		if !slices.Contains(kinds, Resources[i].Kind) {
+			kinds = append(kinds, Resources[i].Kind)
+		}
+	}
+	return kinds, nil
+}
+
+type hit struct {
+	score  float64
+	fields Field
+}
+
+// sortWords sorts the terms of the index if they changed since the last search
+func (idx *Index) sortWords() {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if idx.wordsStale {
+		idx.words = slices.Sorted(maps.Keys(idx.terms))
+		idx.wordsStale = false
+	}
+}
+
+// matchTerm returns the resources containing a word which is, or starts
+// with, the term. The words starting with the term are found with a binary
+// search of the sorted words. The caller must hold the lock.
+func (idx *Index) matchTerm(term string) map[docKey]hit {
+	hits := map[docKey]hit{}
+	start, _ := slices.BinarySearch(idx.words, term)
+	for _, word := range idx.words[start:] {
+		if !strings.HasPrefix(word, term) {
+			break
+		}
+		scale := 1.0
+		if word != term {
+			scale = prefixWeight
+		}
+		// Words removed since they were sorted have no resources
+		docs := idx.terms[word]
+		for key, fields := range docs {
+			var score float64
+			for _, f := range fieldNames {
+				if fields&f.field != 0 {
+					score += f.weight * scale
+				}
+			}
+			h := hits[key]
+			// Keep the best word matching the term, e.g. the whole word over
+			// a longer word it is a prefix of
+			h.score = max(h.score, score)
+			h.fields |= fields
+			hits[key] = h
+		}
+	}
+	return hits
+}
+
+func (q Query) matches(key docKey) bool {
+	if q.Namespace != "" && key.namespace != q.Namespace {
+		return false
+	}
+	return len(q.Kinds) == 0 || slices.Contains(q.Kinds, key.kind)
+}
+
+// Search returns the resources matching all terms of the query, the best
+// matches first. Matches in the name rank above matches in labels, params
+// and results, and annotations rank last.
+func (idx *Index) Search(q Query) (Results, error) {
+	if !idx.HasSynced() {
+		return Results{}, ErrNotSynced
+	}
+	terms := Tokenize(q.Text)
+	if len(terms) == 0 {
+		return Results{}, ErrEmptyQuery
+	}
+	slices.Sort(terms)
+	terms = slices.Compact(terms)
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+
+	idx.sortWords()
+	idx.mu.RLock()
+	var hits map[docKey]hit
+	for _, term := range terms {
+		termHits := idx.matchTerm(term)
+		if hits == nil {
+			hits = map[docKey]hit{}
+			for key, h := range termHits {
+				if q.matches(key) {
+					hits[key] = h
+				}
+			}
+			continue
+		}
+		for key, h := range hits {
+			termHit, ok := termHits[key]
+			if !ok {
+				delete(hits, key)
+				continue
+			}
+			hits[key] = hit{score: h.score + termHit.score, fields: h.fields | termHit.fields}
+		}
+	}
+	resources := idx.resources
+	idx.mu.RUnlock()
+
+	results := make([]Result, 0, len(hits))
+	for key, h := range hits {
+		if q.Allowed != nil && !q.Allowed(resources[key.kind].GVR, key.namespace) {
+			continue
+		}
+		result := Result{
+			Kind:       key.kind,
+			APIVersion: resources[key.kind].GVR.GroupVersion().String(),
+			Namespace:  key.namespace,
+			Name:       key.name,
+			Score:      h.score,
+			Matches:    []string{},
+		}
+		for _, f := range fieldNames {
+			if h.fields&f.field != 0 {
+				result.Matches = append(result.Matches, f.name)
+			}
+		}
+		results = append(results, result)
+	}
+	slices.SortFunc(results, func(a, b Result) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+	return Results{Total: len(results), Results: results[:min(limit, len(results))]}, nil
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package stats aggregates statistics of PipelineRuns and TaskRuns from the
+// run index
+package stats
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/tektoncd/dashboard/pkg/runs"
+)
+
+// Fields runs can be grouped by
+const (
+	GroupByPipeline  = "pipeline"
+	GroupByNamespace = "namespace"
+	GroupByTime      = "time"
+)
+
+// Time bucket sizes
+const (
+	BucketHour = "hour"
+	BucketDay  = "day"
+	BucketWeek = "week"
+)
+
+// ErrNotSynced is returned when the index has not finished its initial list
+var ErrNotSynced = errors.New("run statistics are not available yet")
+
+// Index computes statistics from the runs in the run index
+type Index struct {
+	runs *runs.Index
+}
+
+// New returns an Index computing statistics from the run index
+func New(index *runs.Index) *Index {
+	return &Index{runs: index}
+}
+
+// Query selects the runs to aggregate and how to group them
+type Query struct {
+	Kind runs.Kind
+	// Namespace restricts the runs to this namespace, all runs if empty
+	Namespace string
+	// Pipeline restricts the runs to those of the named Pipeline or Task
+	Pipeline string
+	// Since and Until restrict the runs to those created in this range
+	Since time.Time
+	Until time.Time
+	// GroupBy lists the fields runs are grouped by
+	GroupBy []string
+	// Bucket is the size of the time buckets when grouping by time
+	Bucket string
+	// Allowed restricts the runs to the namespaces the user may list, if set
+	Allowed func(namespace string) bool
+}
+
+// Percentiles summarises a distribution of durations, in seconds
+type Percentiles struct {
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+}
+
+// Group holds the statistics of a group of runs. Only the fields the runs
+// are grouped by are set.
+type Group struct {
+	Pipeline  string     `json:"pipeline,omitempty"`
+	Task      string     `json:"task,omitempty"`
+	Namespace string     `json:"namespace,omitempty"`
+	Bucket    *time.Time `json:"bucket,omitempty"`
+
+	Total     int `json:"total"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	Cancelled int `json:"cancelled"`
+	Running   int `json:"running"`
+	Pending   int `json:"pending"`
+	// SuccessRate is the share of completed runs which succeeded, ignoring
+	// cancelled runs
+	SuccessRate *float64 `json:"successRate,omitempty"`
+	// Duration is the time from the start to the completion of runs
+	Duration *Percentiles `json:"duration,omitempty"`
+	// QueueTime is the time from the creation to the start of runs
+	QueueTime *Percentiles `json:"queueTime,omitempty"`
+
+	durations  []time.Duration
+	queueTimes []time.Duration
+}
+
+// Result holds the statistics computed for a query
+type Result struct {
+	Kind   runs.Kind `json:"kind"`
+	Since  string    `json:"since,omitempty"`
+	Until  string    `json:"until,omitempty"`
+	Groups []*Group  `json:"groups"`
+}
+
+// ParseBucket validates a time bucket size, defaulting to a day
+func ParseBucket(value string) (string, error) {
+	switch value {
+	case "":
+		return BucketDay, nil
+	case BucketHour, BucketDay, BucketWeek:
+		return value, nil
+	default:
+		return "", fmt.Errorf("invalid bucket %q, expected %s, %s or %s", value, BucketHour, BucketDay, BucketWeek)
+	}
+}
+
+// ParseGroupBy validates the fields to group runs by
+func ParseGroupBy(values []string) ([]string, error) {
+	var groupBy []string
+	for _, value := range values {
+		switch value {
+		case GroupByPipeline, "task":
+			value = GroupByPipeline
+		case GroupByNamespace, GroupByTime:
+		default:
+			return nil, fmt.Errorf("invalid groupBy %q, expected %s, %s or %s", value, GroupByPipeline, GroupByNamespace, GroupByTime)
+		}
+		if !slices.Contains(groupBy, value) {
+			groupBy = append(groupBy, value)
+		}
+	}
+	return groupBy, nil
+}
+
+// bucketStart returns the start of the time bucket containing t, in UTC
+func bucketStart(t time.Time, bucket string) time.Time {
+	t = t.UTC()
+	switch bucket {
+	case BucketHour:
+		return t.Truncate(time.Hour)
+	case BucketWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		// Weeks start on Monday
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// percentile returns the nearest-rank percentile of sorted durations in seconds
+func percentile(sorted []time.Duration, p float64) float64 {
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	return sorted[max(rank, 0)].Seconds()
+}
+
+func percentiles(durations []time.Duration) *Percentiles {
+	if len(durations) == 0 {
+		return nil
+	}
+	slices.Sort(durations)
+	return &Percentiles{P50: percentile(durations, 50), P90: percentile(durations, 90)}
+}
+
+func (q Query) matches(r runs.Summary) bool {
+	if !q.Since.IsZero() && r.Created.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !r.Created.Before(q.Until) {
+		return false
+	}
+	return true
+}
+
+// group returns the group of a run, identified by the grouped fields
+func (q Query) group(r runs.Summary) Group {
+	var g Group
+	for _, field := range q.GroupBy {
+		switch field {
+		case GroupByPipeline:
+			if q.Kind == runs.KindTaskRun {
+				g.Task = r.Pipeline
+			} else {
+				g.Pipeline = r.Pipeline
+			}
+		case GroupByNamespace:
+			g.Namespace = r.Namespace
+		case GroupByTime:
+			bucket := bucketStart(r.Created, q.Bucket)
+			g.Bucket = &bucket
+		}
+	}
+	return g
+}
+
+type groupKey struct {
+	definition string
+	namespace  string
+	bucket     time.Time
+}
+
+func (g Group) key() groupKey {
+	key := groupKey{definition: g.Pipeline + g.Task, namespace: g.Namespace}
+	if g.Bucket != nil {
+		key.bucket = *g.Bucket
+	}
+	return key
+}
+
+func (g *Group) add(r runs.Summary) {
+	g.Total++
+	switch r.Status {
+	case runs.StatusSucceeded:
+		g.Succeeded++
+	case runs.StatusFailed:
+		g.Failed++
+	case runs.StatusCancelled:
+		g.Cancelled++
+	case runs.StatusRunning:
+		g.Running++
+	case runs.StatusPending:
+		g.Pending++
+	}
+	if !r.Started.IsZero() {
+		if queueTime := r.Started.Sub(r.Created); queueTime >= 0 {
+			g.queueTimes = append(g.queueTimes, queueTime)
+		}
+	}
+	// Cancelled runs would skew the durations of runs which completed
+	if r.Status == runs.StatusSucceeded || r.Status == runs.StatusFailed {
+		if duration, ok := r.Duration(); ok && duration >= 0 {
+			g.durations = append(g.durations, duration)
+		}
+	}
+}
+
+func (g *Group) finish() {
+	if completed := g.Succeeded + g.Failed; completed > 0 {
+		rate := float64(g.Succeeded) / float64(completed)
+		g.SuccessRate = &rate
+	}
+	g.Duration = percentiles(g.durations)
+	g.QueueTime = percentiles(g.queueTimes)
+}
+
+// Compute aggregates the statistics of the runs selected by the query
+func (idx *Index) Compute(q Query) (Result, error) {
+	if !idx.runs.HasSynced() {
+		return Result{}, ErrNotSynced
+	}
+	groups := map[groupKey]*Group{}
+	for _, u := range idx.runs.List(q.Kind, q.Namespace, q.Pipeline) {
+		r := runs.Summarize(q.Kind, u)
+		if !q.matches(r) || (q.Allowed != nil && !q.Allowed(r.Namespace)) {
+			continue
+		}
+		g := q.group(r)
+		key := g.key()
+		existing, ok := groups[key]
+		if !ok {
+			existing = &g
+			groups[key] = existing
+		}
+		existing.add(r)
+	}
+
+	result := Result{Kind: q.Kind, Groups: make([]*Group, 0, len(groups))}
+	if !q.Since.IsZero() {
+		result.Since = q.Since.UTC().Format(time.RFC3339)
+	}
+	if !q.Until.IsZero() {
+		result.Until = q.Until.UTC().Format(time.RFC3339)
+	}
+	for _, g := range groups {
+		g.finish()
+		result.Groups = append(result.Groups, g)
+	}
+	slices.SortFunc(result.Groups, func(a, b *Group) int {
+		ka, kb := a.key(), b.key()
+		return cmp.Or(
+			cmp.Compare(ka.definition, kb.definition),
+			cmp.Compare(ka.namespace, kb.namespace),
+			ka.bucket.Compare(kb.bucket),
+		)
+	})
+	return result, nil
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// identityHeaders identify the user sending a request, e.g. as set by an
+// authenticating proxy in front of the Dashboard, and change the identity
+// used by the API server to authorize it
+var identityHeaders = []string{"Authorization", "Impersonate-User", "Impersonate-Group", "Impersonate-Uid"}
+
+// impersonateExtraPrefix is the prefix of headers holding extra user attributes
+const impersonateExtraPrefix = "Impersonate-Extra-"
+
+// IdentityHeaders returns the headers identifying the user sending a request,
+// or an empty header if the request carries no identity
+func IdentityHeaders(header http.Header) http.Header {
+	identity := http.Header{}
+	for key, values := range header {
+		if (slices.Contains(identityHeaders, key) || strings.HasPrefix(key, impersonateExtraPrefix)) && len(values) > 0 {
+			identity[key] = values
+		}
+	}
+	return identity
+}
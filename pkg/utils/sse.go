@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventWriter writes Server-Sent Events to a response, flushing each event
+// as soon as it has been written. It is safe for concurrent use.
+type EventWriter struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+// NewEventWriter sets the headers required for an event stream, writes the
+// response status and returns an EventWriter for the response
+func NewEventWriter(response http.ResponseWriter) *EventWriter {
+	response.Header().Set("Content-Type", "text/event-stream")
+	response.Header().Set("Cache-Control", "no-cache")
+	// Ask intermediate proxies not to buffer the stream
+	response.Header().Set("X-Accel-Buffering", "no")
+	response.WriteHeader(http.StatusOK)
+
+	return &EventWriter{writer: MakeFlushWriter(response)}
+}
+
+// WriteEvent writes a single event. The id and event fields are omitted when empty.
+func (e *EventWriter) WriteEvent(id, event, data string) error {
+	var b strings.Builder
+	if id != "" {
+		b.WriteString("id: " + stripNewlines(id) + "\n")
+	}
+	if event != "" {
+		b.WriteString("event: " + stripNewlines(event) + "\n")
+	}
+	for _, line := range strings.Split(data, "\n") {
+		b.WriteString("data: " + strings.TrimSuffix(line, "\r") + "\n")
+	}
+	b.WriteString("\n")
+
+	return e.write(b.String())
+}
+
+// WriteComment writes a comment line, ignored by clients but useful to keep
+// the connection alive
+func (e *EventWriter) WriteComment(comment string) error {
+	return e.write(": " + stripNewlines(comment) + "\n\n")
+}
+
+// Heartbeat writes a comment at the given interval until the context is done
+// or a write fails
+func (e *EventWriter) Heartbeat(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.WriteComment("heartbeat"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (e *EventWriter) write(s string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, err := io.WriteString(e.writer, s)
+	return err
+}
+
+func stripNewlines(s string) string {
+	s = strings.ReplaceAll(s, "\n", "")
+	return strings.ReplaceAll(s, "\r", "")
+}
@@ -1,5 +1,5 @@
 /*
-Copyright 2019-2022 The Tekton Authors
+Copyright 2019-2026 The Tekton Authors
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
@@ -14,10 +14,12 @@ limitations under the License.
 package utils
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	logging "github.com/tektoncd/dashboard/pkg/logging"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 // RespondError - logs and writes an error response with a desired status code
@@ -30,3 +32,13 @@ func RespondError(response http.ResponseWriter, err error, statusCode int) {
 		logging.Log.Error("Write failed: %v", err)
 	}
 }
+
+// StatusCodeForError returns the status code carried by a Kubernetes API error,
+// or http.StatusInternalServerError for any other error
+func StatusCodeForError(err error) int {
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && status.Status().Code != 0 {
+		return int(status.Status().Code)
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,419 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package watchcache serves list and watch requests for Tekton resources
+// from shared informers, so many clients can watch the same resources with
+// a single watch on the API server
+package watchcache
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// eventBufferSize is the number of events kept for each resource so
+	// clients can resume a watch from a recent resource version
+	eventBufferSize = 1000
+	// watcherBufferSize is the number of events queued for a client before it
+	// is considered too slow and its watch is closed
+	watcherBufferSize = 100
+	// defaultWatchTimeout bounds how long a watch lasts when the client does
+	// not set timeoutSeconds, matching the API server's minimum request timeout
+	defaultWatchTimeout = 30 * time.Minute
+)
+
+// resourceInfo describes a resource which can be cached
+type resourceInfo struct {
+	gvr  schema.GroupVersionResource
+	kind string
+}
+
+// SupportedResources are the resources which can be served from the cache, by name
+var SupportedResources = map[string]resourceInfo{
+	"pipelineruns": {gvr: schema.GroupVersionResource{Group: "tekton.dev", Version: "v1", Resource: "pipelineruns"}, kind: "PipelineRun"},
+	"taskruns":     {gvr: schema.GroupVersionResource{Group: "tekton.dev", Version: "v1", Resource: "taskruns"}, kind: "TaskRun"},
+	"pipelines":    {gvr: schema.GroupVersionResource{Group: "tekton.dev", Version: "v1", Resource: "pipelines"}, kind: "Pipeline"},
+	"tasks":        {gvr: schema.GroupVersionResource{Group: "tekton.dev", Version: "v1", Resource: "tasks"}, kind: "Task"},
+	"customruns":   {gvr: schema.GroupVersionResource{Group: "tekton.dev", Version: "v1beta1", Resource: "customruns"}, kind: "CustomRun"},
+}
+
+// watchEvent is an event for a cached object, with its resource version
+// parsed for comparison
+type watchEvent struct {
+	Type   watch.EventType `json:"type"`
+	Object map[string]any  `json:"object"`
+
+	resourceVersion uint64
+	namespace       string
+	name            string
+	labels          map[string]string
+}
+
+func newWatchEvent(eventType watch.EventType, obj *unstructured.Unstructured, resourceVersion uint64) watchEvent {
+	return watchEvent{
+		Type:            eventType,
+		Object:          obj.Object,
+		resourceVersion: resourceVersion,
+		namespace:       obj.GetNamespace(),
+		name:            obj.GetName(),
+		labels:          obj.GetLabels(),
+	}
+}
+
+// filter selects the objects requested by a client
+type filter struct {
+	namespace string
+	labels    labels.Selector
+	fields    fields.Selector
+}
+
+func (f filter) matches(e watchEvent) bool {
+	if f.namespace != "" && e.namespace != f.namespace {
+		return false
+	}
+	if !f.labels.Matches(labels.Set(e.labels)) {
+		return false
+	}
+	return f.fields.Matches(fields.Set{"metadata.name": e.name, "metadata.namespace": e.namespace})
+}
+
+// watcher receives the events matching its filter
+type watcher struct {
+	filter filter
+	// resourceVersion is the resource version the watch started from, the
+	// client has already seen events up to it. Zero for watches starting with
+	// the current state of all objects.
+	resourceVersion uint64
+	events          chan watchEvent
+	// closed is set when events is closed because the client was too slow
+	closed bool
+}
+
+// resourceCache holds the informers and recent events for a single resource
+type resourceCache struct {
+	info      resourceInfo
+	informers []cache.SharedIndexInformer
+
+	mu sync.RWMutex
+	// resourceVersion is the latest resource version observed
+	resourceVersion uint64
+	// oldestResourceVersion is the resource version after which all events
+	// are in the buffer
+	oldestResourceVersion uint64
+	events                []watchEvent
+	watchers              map[*watcher]struct{}
+	synced                bool
+}
+
+// Cache serves list and watch requests for Tekton resources from memory
+type Cache struct {
+	// namespaces are the namespaces cached, all namespaces if empty
+	namespaces []string
+	resources  map[schema.GroupVersionResource]*resourceCache
+	factories  []dynamicinformer.DynamicSharedInformerFactory
+}
+
+// New returns a Cache for the named resources in the given namespaces, or
+// all namespaces if none are provided. Call Run to start filling the cache.
+func New(client dynamic.Interface, namespaces []string, resources []string) (*Cache, error) {
+	c := &Cache{
+		namespaces: namespaces,
+		resources:  map[schema.GroupVersionResource]*resourceCache{},
+	}
+
+	scopes := namespaces
+	if len(scopes) == 0 {
+		scopes = []string{metav1.NamespaceAll}
+	}
+	for _, namespace := range scopes {
+		c.factories = append(c.factories, dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, 0, namespace, nil))
+	}
+
+	for _, name := range resources {
+		info, ok := SupportedResources[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported resource %q for the watch cache", name)
+		}
+		rc := &resourceCache{info: info, watchers: map[*watcher]struct{}{}}
+		for _, factory := range c.factories {
+			rc.informers = append(rc.informers, factory.ForResource(info.gvr).Informer())
+		}
+		c.resources[info.gvr] = rc
+	}
+	return c, nil
+}
+
+// Run fills the cache and keeps it up to date until the context is done
+func (c *Cache) Run(ctx context.Context) error {
+	for _, rc := range c.resources {
+		for _, informer := range rc.informers {
+			if _, err := informer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+				AddFunc:    rc.onAdd,
+				UpdateFunc: rc.onUpdate,
+				DeleteFunc: rc.onDelete,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, factory := range c.factories {
+		factory.Start(ctx.Done())
+	}
+	for _, rc := range c.resources {
+		go rc.waitForSync(ctx)
+	}
+	<-ctx.Done()
+	for _, factory := range c.factories {
+		factory.Shutdown()
+	}
+	return nil
+}
+
+// covers returns true if the cache holds all objects of a namespace, or of
+// all namespaces if namespace is empty
+func (c *Cache) covers(namespace string) bool {
+	if len(c.namespaces) == 0 {
+		return true
+	}
+	return namespace != "" && slices.Contains(c.namespaces, namespace)
+}
+
+func parseResourceVersion(value string) (uint64, bool) {
+	rv, err := strconv.ParseUint(value, 10, 64)
+	return rv, err == nil
+}
+
+// waitForSync marks the resource as synced once all its informers have
+// synced, so requests are only served once the cache is complete
+func (rc *resourceCache) waitForSync(ctx context.Context) {
+	synced := make([]cache.InformerSynced, 0, len(rc.informers))
+	for _, informer := range rc.informers {
+		synced = append(synced, informer.HasSynced)
+	}
+	if !cache.WaitForCacheSync(ctx.Done(), synced...) {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for _, informer := range rc.informers {
+		if rv, ok := parseResourceVersion(informer.LastSyncResourceVersion()); ok && rv > rc.resourceVersion {
+			rc.resourceVersion = rv
+		}
+	}
+	rc.oldestResourceVersion = rc.resourceVersion
+	rc.synced = true
+	logging.Log.Infof("Watch cache for %s synced at resource version %d", rc.info.gvr.Resource, rc.resourceVersion)
+}
+
+func (rc *resourceCache) onAdd(obj any, isInInitialList bool) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	if isInInitialList {
+		rc.observe(u)
+		return
+	}
+	rc.record(watch.Added, u)
+}
+
+func (rc *resourceCache) onUpdate(oldObj, newObj any) {
+	oldU, ok := oldObj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	newU, ok := newObj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	// Resyncs deliver updates for unchanged objects
+	if oldU.GetResourceVersion() == newU.GetResourceVersion() {
+		return
+	}
+	rc.record(watch.Modified, newU)
+}
+
+func (rc *resourceCache) onDelete(obj any) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	rc.record(watch.Deleted, u)
+}
+
+// observe updates the latest resource version for an object in the initial list
+func (rc *resourceCache) observe(u *unstructured.Unstructured) {
+	rv, _ := parseResourceVersion(u.GetResourceVersion())
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rv > rc.resourceVersion {
+		rc.resourceVersion = rv
+	}
+}
+
+// record adds an event to the buffer and sends it to the matching watchers
+func (rc *resourceCache) record(eventType watch.EventType, u *unstructured.Unstructured) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rv, ok := parseResourceVersion(u.GetResourceVersion())
+	if !ok || rv < rc.resourceVersion {
+		// Objects deleted while the informer was disconnected keep their last
+		// resource version, which is older than the events already sent
+		rv = rc.resourceVersion
+	}
+	rc.resourceVersion = rv
+
+	event := newWatchEvent(eventType, u, rv)
+	if !rc.synced {
+		// Clients are only served once the cache is synced, at which point
+		// all events up to the latest resource version are reflected in the list
+		return
+	}
+	if len(rc.events) == eventBufferSize {
+		rc.oldestResourceVersion = rc.events[0].resourceVersion
+		rc.events = slices.Delete(rc.events, 0, 1)
+	}
+	rc.events = append(rc.events, event)
+
+	for w := range rc.watchers {
+		// The watch may have started from a resource version the cache had
+		// not reached yet, e.g. that of a list from the API server
+		if event.resourceVersion <= w.resourceVersion || !w.filter.matches(event) {
+			continue
+		}
+		select {
+		case w.events <- event:
+		default:
+			logging.Log.Debugf("Closing slow watch on %s", rc.info.gvr.Resource)
+			rc.closeWatcher(w)
+		}
+	}
+}
+
+// closeWatcher stops sending events to a watcher, the caller must hold the lock
+func (rc *resourceCache) closeWatcher(w *watcher) {
+	if _, ok := rc.watchers[w]; !ok {
+		return
+	}
+	delete(rc.watchers, w)
+	watchersGauge.WithLabelValues(rc.info.gvr.Resource).Dec()
+	if !w.closed {
+		w.closed = true
+		close(w.events)
+	}
+}
+
+// objects returns the cached objects matching the filter, sorted by
+// namespace and name, the caller must hold the lock
+func (rc *resourceCache) objects(f filter) []watchEvent {
+	var objects []watchEvent
+	for _, informer := range rc.informers {
+		for _, obj := range informer.GetStore().List() {
+			u, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			event := newWatchEvent(watch.Added, u, 0)
+			if f.matches(event) {
+				objects = append(objects, event)
+			}
+		}
+	}
+	slices.SortFunc(objects, func(a, b watchEvent) int {
+		return strings.Compare(a.namespace+"/"+a.name, b.namespace+"/"+b.name)
+	})
+	return objects
+}
+
+// list returns the objects matching the filter and the resource version of
+// the list, or false if the cache cannot serve a list at the requested
+// resource version. Lists without a resource version require the most recent
+// data, which only the API server can guarantee, so are never served from
+// the cache.
+func (rc *resourceCache) list(f filter, resourceVersion string) ([]watchEvent, uint64, bool) {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	if !rc.synced || resourceVersion == "" {
+		return nil, 0, false
+	}
+	if resourceVersion != "0" {
+		rv, ok := parseResourceVersion(resourceVersion)
+		if !ok || rv > rc.resourceVersion {
+			return nil, 0, false
+		}
+	}
+	return rc.objects(f), rc.resourceVersion, true
+}
+
+// watch registers a watcher for events after the requested resource
+// version, returning the events it must be sent first, or false if the cache
+// cannot serve a watch from that resource version
+func (rc *resourceCache) watch(f filter, resourceVersion string) (*watcher, []watchEvent, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if !rc.synced {
+		return nil, nil, false
+	}
+
+	var initial []watchEvent
+	var rv uint64
+	if resourceVersion == "" || resourceVersion == "0" {
+		// Start with the current state of all objects
+		initial = rc.objects(f)
+	} else {
+		var ok bool
+		rv, ok = parseResourceVersion(resourceVersion)
+		if !ok || rv < rc.oldestResourceVersion {
+			return nil, nil, false
+		}
+		for _, event := range rc.events {
+			if event.resourceVersion > rv && f.matches(event) {
+				initial = append(initial, event)
+			}
+		}
+	}
+
+	w := &watcher{filter: f, resourceVersion: rv, events: make(chan watchEvent, watcherBufferSize)}
+	rc.watchers[w] = struct{}{}
+	watchersGauge.WithLabelValues(rc.info.gvr.Resource).Inc()
+	return w, initial, true
+}
+
+func (rc *resourceCache) stopWatch(w *watcher) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.closeWatcher(w)
+}
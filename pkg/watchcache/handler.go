@@ -0,0 +1,228 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+		http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchcache
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/dashboard/pkg/logging"
+	"github.com/tektoncd/dashboard/pkg/utils"
+	"golang.org/x/net/websocket"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// supportedFieldSelectors are the fields which can be selected in the cache
+var supportedFieldSelectors = map[string]bool{"metadata.name": true, "metadata.namespace": true}
+
+// request is a list or watch request which may be served from the cache
+type request struct {
+	resource        *resourceCache
+	filter          filter
+	watch           bool
+	resourceVersion string
+	timeout         time.Duration
+}
+
+// parsePath returns the resource and namespace of a collection URL in the
+// form /apis/{group}/{version}[/namespaces/{namespace}]/{resource}
+func parsePath(path string) (schema.GroupVersionResource, string, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) == 4 && parts[0] == "apis":
+		return schema.GroupVersionResource{Group: parts[1], Version: parts[2], Resource: parts[3]}, "", true
+	case len(parts) == 6 && parts[0] == "apis" && parts[3] == "namespaces":
+		return schema.GroupVersionResource{Group: parts[1], Version: parts[2], Resource: parts[5]}, parts[4], true
+	default:
+		return schema.GroupVersionResource{}, "", false
+	}
+}
+
+// parseRequest returns the request if it can be served from the cache
+func (c *Cache) parseRequest(r *http.Request) (request, bool) {
+	if r.Method != http.MethodGet {
+		return request{}, false
+	}
+	// Identity headers change the identity used by the API server to
+	// authorize a request, so requests carrying them cannot be served from
+	// the cache
+	if len(utils.IdentityHeaders(r.Header)) > 0 {
+		return request{}, false
+	}
+
+	gvr, namespace, ok := parsePath(r.URL.Path)
+	if !ok {
+		return request{}, false
+	}
+	rc, ok := c.resources[gvr]
+	if !ok || !c.covers(namespace) {
+		return request{}, false
+	}
+
+	query := r.URL.Query()
+	// Paginated lists, exact resource versions and streaming lists are
+	// left to the API server
+	if query.Get("continue") != "" || query.Get("resourceVersionMatch") != "" || query.Get("sendInitialEvents") != "" {
+		return request{}, false
+	}
+
+	labelSelector, err := labels.Parse(query.Get("labelSelector"))
+	if err != nil {
+		return request{}, false
+	}
+	fieldSelector, err := fields.ParseSelector(query.Get("fieldSelector"))
+	if err != nil {
+		return request{}, false
+	}
+	for _, requirement := range fieldSelector.Requirements() {
+		if !supportedFieldSelectors[requirement.Field] {
+			return request{}, false
+		}
+	}
+
+	req := request{
+		resource:        rc,
+		filter:          filter{namespace: namespace, labels: labelSelector, fields: fieldSelector},
+		watch:           query.Get("watch") == "true" || query.Get("watch") == "1",
+		resourceVersion: query.Get("resourceVersion"),
+		timeout:         defaultWatchTimeout,
+	}
+	if timeoutSeconds, err := strconv.Atoi(query.Get("timeoutSeconds")); err == nil && timeoutSeconds > 0 {
+		req.timeout = time.Duration(timeoutSeconds) * time.Second
+	}
+	return req, true
+}
+
+// Wrap returns a handler serving list and watch requests for cached
+// resources from the cache, and passing all other requests to h
+func (c *Cache) Wrap(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, ok := c.parseRequest(r)
+		if ok && req.watch {
+			ok = c.serveWatch(w, r, req)
+		} else if ok {
+			ok = c.serveList(w, req)
+		}
+		if !ok {
+			h.ServeHTTP(w, r)
+		}
+	})
+}
+
+// serveList responds with the cached objects, or returns false if the cache
+// cannot serve the request
+func (c *Cache) serveList(w http.ResponseWriter, req request) bool {
+	objects, resourceVersion, ok := req.resource.list(req.filter, req.resourceVersion)
+	if !ok {
+		return false
+	}
+	requestsCounter.WithLabelValues(req.resource.info.gvr.Resource, "list").Inc()
+
+	items := make([]map[string]any, 0, len(objects))
+	for _, object := range objects {
+		items = append(items, object.Object)
+	}
+	list := map[string]any{
+		"apiVersion": req.resource.info.gvr.GroupVersion().String(),
+		"kind":       req.resource.info.kind + "List",
+		"metadata":   map[string]any{"resourceVersion": strconv.FormatUint(resourceVersion, 10)},
+		"items":      items,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		logging.Log.Error("Error encoding cached list: ", err)
+	}
+	return true
+}
+
+// serveWatch streams events to the client, over a websocket for upgrade
+// requests, or returns false if the cache cannot serve the request
+func (c *Cache) serveWatch(w http.ResponseWriter, r *http.Request, req request) bool {
+	watcher, initial, ok := req.resource.watch(req.filter, req.resourceVersion)
+	if !ok {
+		return false
+	}
+	defer req.resource.stopWatch(watcher)
+	requestsCounter.WithLabelValues(req.resource.info.gvr.Resource, "watch").Inc()
+
+	if isWebSocketRequest(r) {
+		websocket.Server{Handler: func(ws *websocket.Conn) {
+			ctx, cancel := context.WithTimeout(r.Context(), req.timeout)
+			defer cancel()
+			// Clients do not send messages, a read only returns when the
+			// connection is closed
+			go func() {
+				_, _ = io.Copy(io.Discard, ws)
+				cancel()
+			}()
+			streamEvents(ctx, watcher, initial, func(data []byte) error {
+				return websocket.Message.Send(ws, string(data))
+			})
+		}}.ServeHTTP(w, r)
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), req.timeout)
+	defer cancel()
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Transfer-Encoding", "chunked")
+	w.WriteHeader(http.StatusOK)
+	writer := utils.MakeFlushWriter(w)
+	streamEvents(ctx, watcher, initial, func(data []byte) error {
+		_, err := writer.Write(append(data, '\n'))
+		return err
+	})
+	return true
+}
+
+// streamEvents sends the initial events then the watcher's events until the
+// context is done, the watcher is closed, or sending fails
+func streamEvents(ctx context.Context, w *watcher, initial []watchEvent, send func([]byte) error) {
+	sendEvent := func(event watchEvent) bool {
+		data, err := json.Marshal(event)
+		if err != nil {
+			logging.Log.Error("Error encoding watch event: ", err)
+			return false
+		}
+		return send(data) == nil
+	}
+
+	for _, event := range initial {
+		if !sendEvent(event) {
+			return
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-w.events:
+			if !ok || !sendEvent(event) {
+				return
+			}
+		}
+	}
+}
+
+func isWebSocketRequest(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
@@ -1,5 +1,5 @@
 /*
-Copyright 2019-2022 The Tekton Authors
+Copyright 2019-2026 The Tekton Authors
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
@@ -16,6 +16,8 @@ import ReconnectingWebSocket from 'reconnecting-websocket';
 const csrfHeader = {
   'Tekton-Client': 'tektoncd/dashboard'
 };
+const csrfTokenCookie = 'tekton-csrf';
+const csrfTokenHeader = 'Tekton-CSRF-Token';
 const defaultOptions = {
   method: 'GET',
   credentials: 'same-origin'
@@ -104,10 +106,23 @@ export function checkStatus(response = {}, stream = false) {
   throw error;
 }
 
+// When the back end is configured with token-based CSRF protection it issues
+// a token in a cookie which must be echoed in a header on each request
+export function getCSRFToken() {
+  const prefix = `${csrfTokenCookie}=`;
+  const cookie = document.cookie
+    .split(';')
+    .map(value => value.trim())
+    .find(value => value.startsWith(prefix));
+  return cookie ? decodeURIComponent(cookie.slice(prefix.length)) : null;
+}
+
 export async function request(uri, options = defaultOptions, stream) {
+  const csrfToken = getCSRFToken();
   const headers = {
     ...options.headers,
-    ...csrfHeader
+    ...csrfHeader,
+    ...(csrfToken && { [csrfTokenHeader]: csrfToken })
   };
 
   return fetch(uri, {
@@ -1,5 +1,5 @@
 /*
-Copyright 2019-2023 The Tekton Authors
+Copyright 2019-2026 The Tekton Authors
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
@@ -17,6 +17,7 @@ import {
   checkStatus,
   get,
   getAPIRoot,
+  getCSRFToken,
   getHeaders,
   getPatchHeaders,
   post,
@@ -38,6 +39,22 @@ describe('getAPIRoot', () => {
   });
 });
 
+describe('getCSRFToken', () => {
+  afterEach(() => {
+    document.cookie = 'tekton-csrf=; expires=Thu, 01 Jan 1970 00:00:00 GMT';
+  });
+
+  it('returns null when no token has been issued', () => {
+    expect(getCSRFToken()).toBeNull();
+  });
+
+  it('returns the token from the cookie', () => {
+    document.cookie = 'other=value';
+    document.cookie = 'tekton-csrf=nonce.signature';
+    expect(getCSRFToken()).toEqual('nonce.signature');
+  });
+});
+
 describe('getHeaders', () => {
   it('returns default headers when called with no params', () => {
     expect(getHeaders()).not.toBeNull();
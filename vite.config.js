@@ -11,6 +11,9 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
+import { readdir, readFile, writeFile } from 'node:fs/promises';
+import { join, resolve } from 'node:path';
+import { brotliCompressSync, gzipSync } from 'node:zlib';
 import { defineConfig } from 'vite';
 import react from '@vitejs/plugin-react';
 import yaml from '@rollup/plugin-yaml';
@@ -22,6 +25,38 @@ const proxyConfig = {
   target: customAPIDomain || 'http://localhost:9097'
 };
 
+// Write brotli and gzip compressed copies of the text resources alongside the
+// originals so the backend can serve them without compressing on each request
+function precompress() {
+  const extensions = /\.(css|html|js|json|svg)$/;
+  const minSize = 1024;
+  let outDir;
+
+  return {
+    name: 'tkn-precompress',
+    apply: 'build',
+    configResolved(config) {
+      outDir = resolve(config.root, config.build.outDir);
+    },
+    async closeBundle() {
+      const files = await readdir(outDir, { recursive: true });
+      await Promise.all(
+        files
+          .filter(file => extensions.test(file))
+          .map(async file => {
+            const path = join(outDir, file);
+            const content = await readFile(path);
+            if (content.length < minSize) {
+              return;
+            }
+            await writeFile(`${path}.br`, brotliCompressSync(content));
+            await writeFile(`${path}.gz`, gzipSync(content, { level: 9 }));
+          })
+      );
+    }
+  };
+}
+
 export default defineConfig(({ mode }) => ({
   root: './',
   base: './',
@@ -29,7 +64,7 @@ export default defineConfig(({ mode }) => ({
     // Relative to outDir
     assetsDir: '.',
     // Relative to the root
-    outDir: 'cmd/dashboard/kodata',
+    outDir: 'cmd/dashboard/web/dist',
     target: 'es2022'
   },
   css: {
@@ -40,7 +75,7 @@ export default defineConfig(({ mode }) => ({
       }
     }
   },
-  plugins: [react({ devTarget: 'es2022' }), yaml()],
+  plugins: [react({ devTarget: 'es2022' }), yaml(), precompress()],
   resolve: {
     extensions: ['.js', '.jsx']
   },